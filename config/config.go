@@ -26,15 +26,52 @@ type ServerConfig struct {
 	MaxHeaderBytes int
 }
 
+// DatabaseConfig stores the configuration of the database. TxIsolation is the isolation level of the
+// transactions of the requests, TxRetries is the number of the retries of the transaction failed on the
+// serialization failure or the deadlock
 type DatabaseConfig struct {
-	Source string
+	Source      string
+	TxIsolation string
+	TxRetries   int
+}
+
+// SongInfoConfig stores the configuration of the external song info provider.
+// Empty URL disables the enrichment of new songs
+type SongInfoConfig struct {
+	URL        string
+	Timeout    int
+	Retries    int
+	RetryDelay int
+}
+
+// EnrichmentConfig stores the configuration of the background enrichment workers.
+// Intervals are set in seconds. JobLease is the time after which the running job is considered
+// abandoned by its instance and returned to the queue, it must exceed the duration of one attempt
+type EnrichmentConfig struct {
+	Workers      int
+	BatchSize    int
+	PollInterval int
+	MaxAttempts  int
+	Backoff      int
+	MaxBackoff   int
+	JobLease     int
+}
+
+// SuggestConfig stores the configuration of the autocomplete cache.
+// CacheTTL is set in seconds, CacheSize is the max number of cached prefixes
+type SuggestConfig struct {
+	CacheTTL  int
+	CacheSize int
 }
 
 // Config stores the configuration of the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	LogLevel string
+	Server     ServerConfig
+	Database   DatabaseConfig
+	SongInfo   SongInfoConfig
+	Enrichment EnrichmentConfig
+	Suggest    SuggestConfig
+	LogLevel   string
 }
 
 // MustLoadFromEnv loads config from .env file
@@ -63,7 +100,28 @@ func MustLoadFromEnv() *Config {
 			MaxHeaderBytes: mustParseDigit(env["SERVER_MAX_HEADER_BYTES"]),
 		},
 		Database: DatabaseConfig{
-			Source: env[dbSourceEnvVar],
+			Source:      env[dbSourceEnvVar],
+			TxIsolation: env["DB_TX_ISOLATION"],
+			TxRetries:   parseDigitOrDefault(env["DB_TX_RETRIES"], 3),
+		},
+		SongInfo: SongInfoConfig{
+			URL:        env["SONG_INFO_URL"],
+			Timeout:    parseDigitOrDefault(env["SONG_INFO_TIMEOUT"], 5),
+			Retries:    parseDigitOrDefault(env["SONG_INFO_RETRIES"], 3),
+			RetryDelay: parseDigitOrDefault(env["SONG_INFO_RETRY_DELAY"], 500),
+		},
+		Enrichment: EnrichmentConfig{
+			Workers:      parseDigitOrDefault(env["ENRICHMENT_WORKERS"], 2),
+			BatchSize:    parseDigitOrDefault(env["ENRICHMENT_BATCH_SIZE"], 10),
+			PollInterval: parseDigitOrDefault(env["ENRICHMENT_POLL_INTERVAL"], 5),
+			MaxAttempts:  parseDigitOrDefault(env["ENRICHMENT_MAX_ATTEMPTS"], 5),
+			Backoff:      parseDigitOrDefault(env["ENRICHMENT_BACKOFF"], 10),
+			MaxBackoff:   parseDigitOrDefault(env["ENRICHMENT_MAX_BACKOFF"], 600),
+			JobLease:     parseDigitOrDefault(env["ENRICHMENT_JOB_LEASE"], 300),
+		},
+		Suggest: SuggestConfig{
+			CacheTTL:  parseDigitOrDefault(env["SUGGEST_CACHE_TTL"], 30),
+			CacheSize: parseDigitOrDefault(env["SUGGEST_CACHE_SIZE"], 10000),
 		},
 		LogLevel: env["LOG_LEVEL"],
 	}
@@ -77,6 +135,14 @@ func mustParseDigit(raw string) int {
 	return num
 }
 
+// parseDigitOrDefault parses an optional numeric value, an empty string gives the defaultValue
+func parseDigitOrDefault(raw string, defaultValue int) int {
+	if raw == "" {
+		return defaultValue
+	}
+	return mustParseDigit(raw)
+}
+
 func ConfigureSlogLogger(logLevel string) {
 	if logLevel == "debug" {
 		slog.SetLogLoggerLevel(slog.LevelDebug)
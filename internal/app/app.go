@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/amicie-monami/music-library/config"
+	"github.com/amicie-monami/music-library/internal/client/songinfo"
+	"github.com/amicie-monami/music-library/internal/enrichment"
+	"github.com/amicie-monami/music-library/internal/repository"
 	"github.com/amicie-monami/music-library/internal/server"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -23,20 +26,32 @@ func Run(ctx context.Context, config *config.Config) {
 
 	runMigrations(db.DB)
 
+	//the failed server stops the workers as well
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
 	server := server.New(ctx, config, db)
+	enrichmentPool := newEnrichmentPool(config, db)
 	var wg sync.WaitGroup
-	wg.Add(1)
+	wg.Add(2)
+
+	if enrichmentPool != nil {
+		slog.Info("starting enrichment workers", "count", config.Enrichment.Workers)
+		enrichmentPool.Start()
+	}
 
 	go func() {
 		defer wg.Done()
+		defer stop()
 		slog.Info("starting server", "addr", config.Server.Addr)
 		if err := server.Run(ctx); err != nil {
 			slog.Error("http server", "msg", err)
 		}
 	}()
 
-	//checks the context for termination signals
+	//checks the context for termination signals, Run returns after the workers have stopped
 	go func() {
+		defer wg.Done()
 		<-ctx.Done()
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -44,11 +59,34 @@ func Run(ctx context.Context, config *config.Config) {
 			slog.Error("http server shutdown", "msg", err)
 		}
 		slog.Info("http server was successfully shutdown")
+
+		if enrichmentPool != nil {
+			if err := enrichmentPool.Shutdown(ctx); err != nil {
+				slog.Error("enrichment workers shutdown", "msg", err)
+			}
+			slog.Info("enrichment workers were successfully stopped")
+		}
 	}()
 
 	wg.Wait()
 }
 
+// newEnrichmentPool creates the background enrichment workers,
+// returns nil if the song info provider isn't configured
+func newEnrichmentPool(config *config.Config, db *sqlx.DB) *enrichment.Pool {
+	if config.SongInfo.URL == "" {
+		slog.Info("song info provider isn't configured, enrichment is disabled")
+		return nil
+	}
+
+	return enrichment.New(
+		config.Enrichment,
+		repository.NewEnrichment(db),
+		repository.NewSong(db),
+		songinfo.New(config.SongInfo),
+	)
+}
+
 // databaseConnect connects to the database at the source address and pings it
 func databaseConnect(source string) *sqlx.DB {
 	db, err := sqlx.Open("pgx", source)
@@ -0,0 +1,107 @@
+package songinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/amicie-monami/music-library/config"
+)
+
+// ErrNotFound is returned when the provider doesn't know the requested song
+var ErrNotFound = errors.New("song info not found")
+
+// SongDetail describes the response of the song info provider
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Client is an http client of the external song info provider
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	retries    int
+	retryDelay time.Duration
+}
+
+func New(config config.SongInfoConfig) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(config.URL, "/"),
+		httpClient: &http.Client{Timeout: time.Duration(config.Timeout) * time.Second},
+		retries:    config.Retries,
+		retryDelay: time.Duration(config.RetryDelay) * time.Millisecond,
+	}
+}
+
+// GetInfo requests the details of the song from the provider [GET /info?group=&song=].
+// Network errors and 5xx responses are retried with a linearly growing delay,
+// other unsuccessful responses are returned immediately
+func (c *Client) GetInfo(ctx context.Context, group string, song string) (*SongDetail, error) {
+	query := url.Values{}
+	query.Set("group", group)
+	query.Set("song", song)
+	endpoint := fmt.Sprintf("%s/info?%s", c.baseURL, query.Encode())
+
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			slog.Debug("retry song info request", "attempt", attempt, "err", lastErr)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.retryDelay * time.Duration(attempt)):
+			}
+		}
+
+		detail, retryable, err := c.getInfo(ctx, endpoint)
+		if err == nil {
+			return detail, nil
+		}
+
+		if !retryable {
+			return nil, err
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("song info request failed after %d attempts: %w", c.retries+1, lastErr)
+}
+
+// getInfo executes a single request, the second return value reports whether the request can be retried
+func (c *Client) getInfo(ctx context.Context, endpoint string) (*SongDetail, bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusOK:
+	case response.StatusCode == http.StatusNotFound:
+		return nil, false, ErrNotFound
+	case response.StatusCode >= 500:
+		return nil, true, fmt.Errorf("song info provider responded with status=%d", response.StatusCode)
+	default:
+		return nil, false, fmt.Errorf("song info provider responded with status=%d", response.StatusCode)
+	}
+
+	var detail SongDetail
+	if err := json.NewDecoder(response.Body).Decode(&detail); err != nil {
+		return nil, false, fmt.Errorf("failed to decode song info: %w", err)
+	}
+
+	return &detail, false, nil
+}
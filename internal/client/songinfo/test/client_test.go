@@ -0,0 +1,88 @@
+package songinfo_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/config"
+	"github.com/amicie-monami/music-library/internal/client/songinfo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInfo(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Responses   []int
+		Found       bool
+		NotFound    bool
+	}{
+		{
+			Description: "Provider responds with song info",
+			Responses:   []int{http.StatusOK},
+			Found:       true,
+		},
+		{
+			Description: "Provider recovers after failures",
+			Responses:   []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			Found:       true,
+		},
+		{
+			Description: "Provider doesn't know the song",
+			Responses:   []int{http.StatusNotFound},
+			NotFound:    true,
+		},
+		{
+			Description: "Provider rejects the request",
+			Responses:   []int{http.StatusBadRequest},
+		},
+		{
+			Description: "Provider is unavailable",
+			Responses:   []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			requests := 0
+			provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/info", r.URL.Path)
+				assert.Equal(t, "Group", r.URL.Query().Get("group"))
+				assert.Equal(t, "Song", r.URL.Query().Get("song"))
+
+				code := tc.Responses[requests]
+				requests++
+
+				w.WriteHeader(code)
+				if code == http.StatusOK {
+					json.NewEncoder(w).Encode(songinfo.SongDetail{
+						ReleaseDate: "16.07.2006",
+						Text:        "Ooh baby, don't you know I suffer?\n\nOoh baby, can you hear me moan?",
+						Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+					})
+				}
+			}))
+			defer provider.Close()
+
+			client := songinfo.New(config.SongInfoConfig{URL: provider.URL, Timeout: 1, Retries: 2, RetryDelay: 1})
+			detail, err := client.GetInfo(context.Background(), "Group", "Song")
+
+			assert.Equal(t, len(tc.Responses), requests)
+			assert.Equal(t, tc.NotFound, errors.Is(err, songinfo.ErrNotFound))
+
+			if !tc.Found {
+				assert.Error(t, err)
+				assert.Nil(t, detail)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, "16.07.2006", detail.ReleaseDate)
+				assert.Equal(t, "https://www.youtube.com/watch?v=Xsp3_a-PMTw", detail.Link)
+			}
+		})
+	}
+}
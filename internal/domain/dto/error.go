@@ -2,30 +2,144 @@ package dto
 
 import (
 	"fmt"
+	"net/http"
 )
 
+// Kinds of the errors. The kind is sent in the code field of the error body,
+// unlike the message it doesn't change, so the clients can rely on it
+const (
+	KindBadRequest   = "bad_request"
+	KindValidation   = "validation_failed"
+	KindNotFound     = "not_found"
+	KindConflict     = "conflict"
+	KindPrecondition = "precondition_failed"
+	KindUnauthorized = "unauthorized"
+	KindRateLimited  = "rate_limited"
+	KindInternal     = "internal_error"
+	KindUnavailable  = "unavailable"
+)
+
+// statusKinds maps the http status codes of the errors to their kinds
+var statusKinds = map[int]string{
+	http.StatusBadRequest:          KindBadRequest,
+	http.StatusUnprocessableEntity: KindValidation,
+	http.StatusNotFound:            KindNotFound,
+	http.StatusConflict:            KindConflict,
+	http.StatusPreconditionFailed:  KindPrecondition,
+	http.StatusUnauthorized:        KindUnauthorized,
+	http.StatusTooManyRequests:     KindRateLimited,
+	http.StatusInternalServerError: KindInternal,
+	http.StatusServiceUnavailable:  KindUnavailable,
+}
+
+// StatusKind returns the kind of the error with the http status code, the empty string if the code isn't an error code
+func StatusKind(code int) string {
+	return statusKinds[code]
+}
+
 type Error struct {
-	Message  string `json:"message"`
-	Details  any    `json:"details,omitempty"`
-	Code     int    `json:"-"`
-	DebugMsg any    `json:"-"`
-	Location string `json:"-"`
+	Kind     string       `json:"code"`
+	Message  string       `json:"message"`
+	Details  any          `json:"details,omitempty"`
+	Fields   []FieldError `json:"fields,omitempty"`
+	Code     int          `json:"-"`
+	DebugMsg any          `json:"-"`
+	Location string       `json:"-"`
+}
+
+// FieldError describes the invalid field of the request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 func NewError(code int, message string, soruce string, details any, debugmsg any) *Error {
-	return &Error{Message: message, Code: code, Details: details, DebugMsg: debugmsg}
+	return &Error{Kind: statusKinds[code], Message: message, Code: code, Details: details, DebugMsg: debugmsg, Location: soruce}
+}
+
+// NewNotFoundError returns the error of the missing entity addressed by the request
+func NewNotFoundError(message string, source string, details any) *Error {
+	return NewError(http.StatusNotFound, message, source, details, nil)
+}
+
+// NewConflictError returns the error of the request which conflicts with the current state of the entity
+func NewConflictError(message string, source string, details any) *Error {
+	return NewError(http.StatusConflict, message, source, details, nil)
+}
+
+// NewPreconditionFailedError returns the error of the conditional request which condition is false,
+// e.g. the entity has been changed since the client has read it
+func NewPreconditionFailedError(message string, source string, details any) *Error {
+	return NewError(http.StatusPreconditionFailed, message, source, details, nil)
+}
+
+// NewValidationError returns the error of the well-formed request body with the invalid fields
+func NewValidationError(message string, source string, fields ...FieldError) *Error {
+	err := NewError(http.StatusUnprocessableEntity, message, source, nil, nil)
+	err.Fields = fields
+	return err
+}
+
+// NewUnauthorizedError returns the error of the request without the valid credentials
+func NewUnauthorizedError(message string, source string, details any) *Error {
+	return NewError(http.StatusUnauthorized, message, source, details, nil)
+}
+
+// NewRateLimitedError returns the error of the client which has sent too many requests
+func NewRateLimitedError(message string, source string, details any) *Error {
+	return NewError(http.StatusTooManyRequests, message, source, details, nil)
+}
+
+// NewUnavailableError returns the error of the temporarily unavailable dependency, the request can be retried
+func NewUnavailableError(message string, source string, debugmsg any) *Error {
+	return NewError(http.StatusServiceUnavailable, message, source, nil, debugmsg)
+}
+
+// Problem is the error body of RFC 7807, the code of the error is sent as the extension member
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// problemTypePrefix is the prefix of the problem types, the type is the kind of the error
+const problemTypePrefix = "/problems/"
+
+// Problem returns the problem details of the error, the instance identifies the occurrence of the problem
+func (e *Error) Problem(instance string) *Problem {
+	detail := e.Message
+	if e.Details != nil && e.Details != "" {
+		detail = fmt.Sprintf("%s: %v", e.Message, e.Details)
+	}
+
+	return &Problem{
+		Type:     problemTypePrefix + e.Kind,
+		Title:    http.StatusText(e.Code),
+		Status:   e.Code,
+		Detail:   detail,
+		Instance: instance,
+		Code:     e.Kind,
+		Errors:   e.Fields,
+	}
 }
 
 func (e *Error) Error() string {
 	var msg string
 
-	msg += fmt.Sprintf("code=%d message=%s ", e.Code, e.Message)
+	msg += fmt.Sprintf("code=%d kind=%s message=%s ", e.Code, e.Kind, e.Message)
 	if e.Location != "" {
-		msg += fmt.Sprintf("source=%v ", e.Details)
+		msg += fmt.Sprintf("source=%v ", e.Location)
 	}
 	if e.Details != nil {
 		msg += fmt.Sprintf("details=%v ", e.Details)
 	}
+	if len(e.Fields) != 0 {
+		msg += fmt.Sprintf("fields=%v ", e.Fields)
+	}
 
 	if e.DebugMsg != nil {
 		msg += fmt.Sprintf("debug=%v", e.DebugMsg)
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type Song struct {
 	ID    int64  `json:"song_id,omitempty"`
 	Group string `json:"group,omitempty"`
@@ -13,10 +15,134 @@ type SongDetails struct {
 }
 
 type SongWithDetails struct {
-	ID          int64   `json:"song_id,omitempty" db:"song_id"`
-	Group       string  `json:"group,omitempty" db:"group_name"`
-	Title       string  `json:"song,omitempty" db:"song_name"`
-	ReleaseDate *string `json:"release_date,omitempty" db:"release_date"`
-	Text        *string `json:"text,omitempty" db:"text"`
-	Link        *string `json:"link,omitempty" db:"link"`
+	ID          int64      `json:"song_id,omitempty" db:"song_id"`
+	Group       string     `json:"group,omitempty" db:"group_name"`
+	Title       string     `json:"song,omitempty" db:"song_name"`
+	ReleaseDate *string    `json:"release_date,omitempty" db:"release_date"`
+	Text        *string    `json:"text,omitempty" db:"text"`
+	Link        *string    `json:"link,omitempty" db:"link"`
+	Tags        StringList `json:"tags,omitempty" db:"tags"`
+	Similarity  *float32   `json:"similarity,omitempty" db:"similarity"`
+	Version     int64      `json:"-" db:"version"`
+	ETag        string     `json:"etag,omitempty" db:"-"`
+}
+
+type EnrichmentJob struct {
+	SongID        int64     `json:"song_id" db:"song_id"`
+	Status        string    `json:"status" db:"status"`
+	Attempts      int       `json:"attempts" db:"attempts"`
+	LastError     *string   `json:"last_error,omitempty" db:"last_error"`
+	NextAttemptAt time.Time `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type Artist struct {
+	ID         int64  `json:"artist_id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	SongsCount int64  `json:"songs_count" db:"songs_count"`
+}
+
+type Album struct {
+	ID          int64         `json:"album_id" db:"id"`
+	Title       string        `json:"title" db:"title"`
+	ReleaseDate *string       `json:"release_date,omitempty" db:"release_date"`
+	CoverLink   *string       `json:"cover_link,omitempty" db:"cover_link"`
+	TracksCount int64         `json:"tracks_count" db:"tracks_count"`
+	Tracks      []*AlbumTrack `json:"tracks,omitempty" db:"-"`
+}
+
+type AlbumTrack struct {
+	Position int64  `json:"position" db:"position"`
+	SongID   int64  `json:"song_id" db:"song_id"`
+	Group    string `json:"group" db:"group_name"`
+	Title    string `json:"song" db:"song_name"`
+}
+
+type Tag struct {
+	ID         int64  `json:"tag_id" db:"id"`
+	Name       string `json:"name" db:"name"`
+	Kind       string `json:"kind" db:"kind"`
+	UsageCount int64  `json:"usage_count,omitempty" db:"usage_count"`
+}
+
+type SongLink struct {
+	ID       int64  `json:"link_id" db:"id"`
+	SongID   int64  `json:"song_id" db:"song_id"`
+	Platform string `json:"platform" db:"platform"`
+	URL      string `json:"url" db:"url"`
+	Primary  bool   `json:"primary" db:"is_primary"`
+}
+
+type LyricsSearchResult struct {
+	SongID   int64   `json:"song_id" db:"song_id"`
+	Group    string  `json:"group" db:"group_name"`
+	Title    string  `json:"song" db:"song_name"`
+	Rank     float32 `json:"rank" db:"rank"`
+	Headline *string `json:"headline,omitempty" db:"headline"`
+}
+
+type Suggestion struct {
+	ID         int64  `json:"id" db:"id"`
+	Value      string `json:"value" db:"value"`
+	Group      string `json:"group,omitempty" db:"group_name"`
+	Popularity int64  `json:"-" db:"popularity"`
+}
+
+// ImportSongResult is the result of the import of the row, Status takes one value from [created, skipped, failed]
+type ImportSongResult struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	SongID *int64 `json:"song_id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// SongDuplicates is the group of the songs with the same group and song names,
+// Match takes one value from [exact, normalized]
+type SongDuplicates struct {
+	Match string   `json:"match" db:"match"`
+	Group string   `json:"group" db:"group_name"`
+	Title string   `json:"song" db:"song_name"`
+	Songs SongList `json:"songs" db:"songs"`
+}
+
+// LyricsRevision is the stored version of the lyrics of the song, Text is nil in the lists of the revisions
+// and if the lyrics have been cleared by the revision
+type LyricsRevision struct {
+	Revision  int64     `json:"revision" db:"revision"`
+	Author    string    `json:"author" db:"author"`
+	Reason    string    `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Text      *string   `json:"text,omitempty" db:"text"`
+}
+
+// LyricsDiffHunk is the hunk of the diff of the lyrics, Couplet is the number of the couplet
+// of the first changed line starting from 1
+type LyricsDiffHunk struct {
+	Header  string   `json:"header"`
+	Couplet int      `json:"couplet"`
+	Lines   []string `json:"lines"`
+}
+
+// SyncedLyricsLine is the timed line of the lyrics, Line starts from 1. The line lasts until the next line,
+// EndMs is nil for the last line
+type SyncedLyricsLine struct {
+	Line    int               `json:"line" db:"line_no"`
+	StartMs int64             `json:"start_ms" db:"start_ms"`
+	EndMs   *int64            `json:"end_ms" db:"end_ms"`
+	Text    string            `json:"text" db:"text"`
+	Words   SyncedLyricsWords `json:"words,omitempty" db:"words"`
+}
+
+type SyncedLyricsWord struct {
+	StartMs int64  `json:"start_ms"`
+	Text    string `json:"text"`
+}
+
+type LyricsTranslation struct {
+	Lang      string    `json:"lang" db:"lang"`
+	Text      string    `json:"text" db:"text"`
+	Author    string    `json:"author" db:"author"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -1,5 +1,7 @@
 package dto
 
+import "encoding/json"
+
 type UpdateSongRequest struct {
 	Group       string `json:"group,omitempty"`
 	Song        string `json:"song,omitempty"`
@@ -17,3 +19,80 @@ type GetSongDetailsRequest struct {
 	Group string `json:"group"`
 	Song  string `json:"song"`
 }
+
+type AddArtistRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateArtistRequest struct {
+	Name string `json:"name"`
+}
+
+type AddAlbumRequest struct {
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	CoverLink   string `json:"cover_link,omitempty"`
+}
+
+type UpdateAlbumRequest struct {
+	Title       string `json:"title,omitempty"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	CoverLink   string `json:"cover_link,omitempty"`
+}
+
+type SetAlbumTracksRequest struct {
+	Songs []int64 `json:"songs"`
+}
+
+type SongTagsRequest struct {
+	Tags []string `json:"tags"`
+	Kind string   `json:"kind,omitempty"`
+}
+
+type AddSongLinkRequest struct {
+	URL     string `json:"url"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type UpdateSongLinkRequest struct {
+	URL     string `json:"url,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type SearchSongsRequest struct {
+	Filter json.RawMessage `json:"filter,omitempty" swaggertype:"object"`
+	Sort   []string        `json:"sort,omitempty"`
+	Fields []string        `json:"fields,omitempty"`
+	Limit  int64           `json:"limit,omitempty"`
+	Offset int64           `json:"offset,omitempty"`
+	Cursor string          `json:"cursor,omitempty"`
+	Total  string          `json:"total,omitempty"`
+}
+
+// ImportSongRow is the row of the songs import file
+type ImportSongRow struct {
+	Group       string `json:"group"`
+	Song        string `json:"song"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Link        string `json:"link,omitempty"`
+}
+
+type MergeSongsRequest struct {
+	Duplicates []int64 `json:"duplicates"`
+}
+
+type SaveLyricsTranslationRequest struct {
+	Text string `json:"text"`
+}
+
+type UpdateCoupletRequest struct {
+	Text string `json:"text"`
+}
+
+// InsertCoupletRequest is the couplet inserted before the couplet at the Position,
+// the couplet without the position is appended to the lyrics
+type InsertCoupletRequest struct {
+	Text     string `json:"text"`
+	Position *int   `json:"position,omitempty"`
+}
@@ -4,9 +4,13 @@ type AddSongResponse struct {
 	Song *Song `json:"song"`
 }
 
+// GetSongTextResponse is the page of the couplets, Translation is aligned with the couplets
+// and has the null items for the couplets without the translation
 type GetSongTextResponse struct {
-	SongID   int64    `json:"song_id"`
-	Couplets []string `json:"couplets"`
+	SongID      int64     `json:"song_id"`
+	Couplets    []string  `json:"couplets"`
+	Lang        string    `json:"lang,omitempty"`
+	Translation []*string `json:"translation,omitempty"`
 }
 
 type GetSongDetailsResponse struct {
@@ -14,5 +18,111 @@ type GetSongDetailsResponse struct {
 }
 
 type GetSongsResponse struct {
-	Songs []*SongWithDetails `json:"songs"`
+	Songs       []*SongWithDetails `json:"songs"`
+	NextCursor  *string            `json:"next_cursor,omitempty"`
+	HasMore     bool               `json:"has_more"`
+	Total       *int64             `json:"total,omitempty"`
+	TotalApprox bool               `json:"total_approx,omitempty"`
+}
+
+type GetEnrichmentJobResponse struct {
+	Job *EnrichmentJob `json:"job"`
+}
+
+type GetArtistsResponse struct {
+	Artists []*Artist `json:"artists"`
+}
+
+type GetArtistResponse struct {
+	Artist *Artist `json:"artist"`
+}
+
+type GetAlbumsResponse struct {
+	Albums []*Album `json:"albums"`
+}
+
+type GetAlbumResponse struct {
+	Album *Album `json:"album"`
+}
+
+type GetTagsResponse struct {
+	Tags []*Tag `json:"tags"`
+}
+
+type GetSongTagsResponse struct {
+	SongID int64  `json:"song_id"`
+	Tags   []*Tag `json:"tags"`
+}
+
+type GetSongLinksResponse struct {
+	SongID int64       `json:"song_id"`
+	Links  []*SongLink `json:"links"`
+}
+
+type SearchLyricsResponse struct {
+	Query   string                `json:"q"`
+	Lang    string                `json:"lang"`
+	Results []*LyricsSearchResult `json:"results"`
+}
+
+type SuggestResponse struct {
+	Suggestions []*Suggestion `json:"suggestions"`
+}
+
+type ImportSongsResponse struct {
+	DryRun  bool                `json:"dry_run"`
+	Created int                 `json:"created"`
+	Skipped int                 `json:"skipped"`
+	Failed  int                 `json:"failed"`
+	Rows    []*ImportSongResult `json:"rows"`
+}
+
+type GetSongDuplicatesResponse struct {
+	Duplicates []*SongDuplicates `json:"duplicates"`
+}
+
+type MergeSongsResponse struct {
+	Song   *SongWithDetails `json:"song"`
+	Merged []int64          `json:"merged"`
+}
+
+type GetLyricsRevisionsResponse struct {
+	SongID    int64             `json:"song_id"`
+	Revisions []*LyricsRevision `json:"revisions"`
+}
+
+type GetLyricsDiffResponse struct {
+	SongID  int64             `json:"song_id"`
+	From    int64             `json:"from"`
+	To      int64             `json:"to"`
+	Unified string            `json:"unified"`
+	Hunks   []*LyricsDiffHunk `json:"hunks"`
+}
+
+type RestoreLyricsRevisionResponse struct {
+	SongID   int64           `json:"song_id"`
+	Revision *LyricsRevision `json:"revision"`
+}
+
+type GetSyncedLyricsResponse struct {
+	SongID int64               `json:"song_id"`
+	Lines  []*SyncedLyricsLine `json:"lines"`
+}
+
+// GetSyncedLyricsLineResponse is the line sung at AtMs, Line is nil before the first line
+type GetSyncedLyricsLineResponse struct {
+	SongID int64             `json:"song_id"`
+	AtMs   int64             `json:"at_ms"`
+	Line   *SyncedLyricsLine `json:"line"`
+	Next   *SyncedLyricsLine `json:"next,omitempty"`
+}
+
+type GetLyricsTranslationsResponse struct {
+	SongID       int64                `json:"song_id"`
+	Translations []*LyricsTranslation `json:"translations"`
+}
+
+type SaveLyricsTranslationResponse struct {
+	SongID      int64              `json:"song_id"`
+	Translation *LyricsTranslation `json:"translation"`
 }
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a list of strings scanned from a json array column
+type StringList []string
+
+func (l *StringList) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, l)
+	case string:
+		return json.Unmarshal([]byte(value), l)
+	default:
+		return fmt.Errorf("unsupported type %T of the string list", src)
+	}
+}
+
+// SongList is a list of songs scanned from a json array column
+type SongList []*SongWithDetails
+
+func (l *SongList) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, l)
+	case string:
+		return json.Unmarshal([]byte(value), l)
+	default:
+		return fmt.Errorf("unsupported type %T of the song list", src)
+	}
+}
+
+// SyncedLyricsWords is a list of the timed words scanned from a json array column
+type SyncedLyricsWords []SyncedLyricsWord
+
+func (l *SyncedLyricsWords) Scan(src any) error {
+	switch value := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(value, l)
+	case string:
+		return json.Unmarshal([]byte(value), l)
+	default:
+		return fmt.Errorf("unsupported type %T of the synced lyrics words", src)
+	}
+}
@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+var (
+	ValidAlbumID = int64(5)
+)
+
+type AlbumRepo struct{}
+
+///
+
+func (m *AlbumRepo) GetAlbums(ctx context.Context, title string, limit int64, offset int64) ([]*dto.Album, error) {
+	return []*dto.Album{{ID: ValidAlbumID, Title: "Album"}}, nil
+}
+
+///
+
+func (m *AlbumRepo) GetAlbum(ctx context.Context, id int64) (*dto.Album, error) {
+	if id != ValidAlbumID {
+		return nil, dto.NewNotFoundError("album not found", "mock", fmt.Sprintf("id=%d", id))
+	}
+	return &dto.Album{ID: id, Title: "Album", Tracks: []*dto.AlbumTrack{{Position: 1, SongID: ValidSongID}}}, nil
+}
+
+///
+
+func (m *AlbumRepo) Create(ctx context.Context, album *model.Album) error {
+	album.ID = ValidAlbumID
+	return nil
+}
+
+///
+
+func (m *AlbumRepo) Update(ctx context.Context, album *model.Album) error {
+	if album.ID != ValidAlbumID {
+		return dto.NewNotFoundError("album not found", "mock", nil)
+	}
+	return nil
+}
+
+///
+
+func (m *AlbumRepo) Delete(ctx context.Context, id int64) error {
+	if id != ValidAlbumID {
+		return dto.NewNotFoundError("album not found", "mock", nil)
+	}
+	return nil
+}
+
+///
+
+func (m *AlbumRepo) SetTracks(ctx context.Context, albumID int64, songIDs []int64) error {
+	if albumID != ValidAlbumID {
+		return dto.NewNotFoundError("album not found", "mock", nil)
+	}
+
+	for _, songID := range songIDs {
+		if songID != ValidSongID {
+			return dto.NewNotFoundError("song not found", "mock", nil)
+		}
+	}
+	return nil
+}
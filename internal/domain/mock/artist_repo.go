@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+var (
+	ValidArtistID      = int64(7)
+	ArtistIDWithSongs  = int64(8)
+	ExistingArtistName = "Queen"
+)
+
+type ArtistRepo struct{}
+
+///
+
+func (m *ArtistRepo) GetArtists(ctx context.Context, name string, limit int64, offset int64) ([]*dto.Artist, error) {
+	return []*dto.Artist{{ID: ValidArtistID, Name: ValidGroupName}}, nil
+}
+
+///
+
+func (m *ArtistRepo) GetArtist(ctx context.Context, id int64) (*dto.Artist, error) {
+	if id != ValidArtistID && id != ArtistIDWithSongs {
+		return nil, dto.NewNotFoundError("artist not found", "mock", fmt.Sprintf("id=%d", id))
+	}
+	return &dto.Artist{ID: id, Name: ValidGroupName}, nil
+}
+
+///
+
+func (m *ArtistRepo) Create(ctx context.Context, artist *model.Artist) error {
+	if artist.Name == ExistingArtistName {
+		return dto.NewConflictError("artist already exists", "mock", nil)
+	}
+	artist.ID = ValidArtistID
+	return nil
+}
+
+///
+
+func (m *ArtistRepo) Rename(ctx context.Context, artist *model.Artist) error {
+	if artist.ID != ValidArtistID {
+		return dto.NewNotFoundError("artist not found", "mock", nil)
+	}
+
+	if artist.Name == ExistingArtistName {
+		return dto.NewConflictError("artist already exists", "mock", nil)
+	}
+	return nil
+}
+
+///
+
+func (m *ArtistRepo) Delete(ctx context.Context, id int64) error {
+	if id == ArtistIDWithSongs {
+		return dto.NewConflictError("artist has songs, delete or move them first", "mock", nil)
+	}
+
+	if id != ValidArtistID {
+		return dto.NewNotFoundError("artist not found", "mock", nil)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+var (
+	RunningJobSongID = int64(33)
+)
+
+type EnrichmentRepo struct {
+	mu sync.Mutex
+	// Queue stores the jobs which will be returned by the next Claim
+	Queue []*model.EnrichmentJob
+	// Completed stores the song ids of the completed jobs
+	Completed []int64
+	// Failed stores the song ids of the failed jobs with the next attempt time
+	Failed map[int64]*time.Time
+}
+
+///
+
+func (m *EnrichmentRepo) GetJob(ctx context.Context, songID int64) (*dto.EnrichmentJob, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("enrichment job not found", "mock", fmt.Sprintf("song_id=%d", songID))
+	}
+	return &dto.EnrichmentJob{SongID: songID, Status: "done", Attempts: 1}, nil
+}
+
+///
+
+func (m *EnrichmentRepo) Retry(ctx context.Context, songID int64) (*dto.EnrichmentJob, error) {
+	if songID == RunningJobSongID {
+		return nil, dto.NewConflictError("enrichment job is running", "mock", nil)
+	}
+
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	return &dto.EnrichmentJob{SongID: songID, Status: "pending"}, nil
+}
+
+///
+
+func (m *EnrichmentRepo) EnqueueEmpty(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *EnrichmentRepo) Claim(ctx context.Context, limit int) ([]*model.EnrichmentJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := min(limit, len(m.Queue))
+	jobs := m.Queue[:count]
+	m.Queue = m.Queue[count:]
+	return jobs, nil
+}
+
+func (m *EnrichmentRepo) Complete(ctx context.Context, songID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Completed = append(m.Completed, songID)
+	return nil
+}
+
+func (m *EnrichmentRepo) Fail(ctx context.Context, songID int64, reason string, nextAttemptAt *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.Failed == nil {
+		m.Failed = make(map[int64]*time.Time)
+	}
+	m.Failed[songID] = nextAttemptAt
+	return nil
+}
+
+func (m *EnrichmentRepo) Release(ctx context.Context, songID int64) error {
+	return nil
+}
+
+func (m *EnrichmentRepo) ResetRunning(ctx context.Context, lease time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// Processed returns the number of jobs with recorded result
+func (m *EnrichmentRepo) Processed() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.Completed) + len(m.Failed)
+}
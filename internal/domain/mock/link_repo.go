@@ -0,0 +1,57 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+var (
+	ValidLinkID = int64(3)
+)
+
+type LinkRepo struct{}
+
+///
+
+func (m *LinkRepo) GetLinks(ctx context.Context, songID int64) ([]*dto.SongLink, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+	return []*dto.SongLink{validLink()}, nil
+}
+
+///
+
+func (m *LinkRepo) AddLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error) {
+	if link.SongID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	link.ID = ValidLinkID + 1
+	added := &dto.SongLink{ID: link.ID, SongID: link.SongID, Platform: link.Platform, URL: link.URL, Primary: link.Primary}
+	return []*dto.SongLink{validLink(), added}, nil
+}
+
+///
+
+func (m *LinkRepo) UpdateLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error) {
+	if link.SongID != ValidSongID || link.ID != ValidLinkID {
+		return nil, dto.NewNotFoundError("link not found", "mock", nil)
+	}
+	return []*dto.SongLink{validLink()}, nil
+}
+
+///
+
+func (m *LinkRepo) DeleteLink(ctx context.Context, songID int64, linkID int64) ([]*dto.SongLink, error) {
+	if songID != ValidSongID || linkID != ValidLinkID {
+		return nil, dto.NewNotFoundError("link not found", "mock", nil)
+	}
+	return []*dto.SongLink{}, nil
+}
+
+func validLink() *dto.SongLink {
+	return &dto.SongLink{ID: ValidLinkID, SongID: ValidSongID, Platform: model.PlatformSpotify, URL: "https://open.spotify.com/track/12", Primary: true}
+}
@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+)
+
+type SearchRepo struct {
+	// Lang is the search configuration of the last query
+	Lang string
+	// SuggestCalls is the number of the Suggest calls
+	SuggestCalls int
+}
+
+///
+
+func (m *SearchRepo) SearchLyrics(ctx context.Context, q string, lang string, limit int64, offset int64) ([]*dto.LyricsSearchResult, error) {
+	m.Lang = lang
+
+	headline := "I see a <b>red</b> <b>door</b>"
+	return []*dto.LyricsSearchResult{{SongID: ValidSongID, Group: ValidGroupName, Title: ValidSongName, Rank: 0.1, Headline: &headline}}, nil
+}
+
+///
+
+func (m *SearchRepo) Suggest(ctx context.Context, kind string, prefix string, limit int64) ([]*dto.Suggestion, error) {
+	m.SuggestCalls++
+
+	if kind == "song" {
+		return []*dto.Suggestion{{ID: ValidSongID, Value: ValidSongName, Group: ValidGroupName}}, nil
+	}
+	return []*dto.Suggestion{{ID: ValidArtistID, Value: ExistingArtistName}}, nil
+}
@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/domain/model"
@@ -13,9 +14,36 @@ var (
 	ValidGroupName        = "Group12"
 	ValidSongID           = int64(12)
 	SongIDWithoutTextData = int64(89)
+	ExistingSongName      = "Existing"
+	LegacyDuplicateSongID = int64(13)
+	ValidSongVersion      = int64(3)
+	// LatestLyricsRevision is the number of the latest of the lyrics revisions of the valid song
+	LatestLyricsRevision = int64(2)
+	// TranslationLang is the language of the translation of the valid song, it has one couplet less than the text
+	TranslationLang = "en-US"
 )
 
-type SongRepo struct{}
+type SongRepo struct {
+	// UpdatedSong stores the last song passed to UpdateSong
+	UpdatedSong *model.Song
+	// UpdatedDetails stores the last details passed to UpdateSongDetails
+	UpdatedDetails *model.SongDetail
+	// Aggregation stores the last aggregation passed to GetSongs
+	Aggregation map[string]any
+	// ImportedBatches stores the sizes of the batches passed to ImportSongs
+	ImportedBatches []int
+	// ExportedSongs are the songs returned by ExportSongs
+	ExportedSongs []*dto.SongWithDetails
+	// DuplicatesMatch stores the last match passed to GetDuplicates
+	DuplicatesMatch string
+	// ChangeAuthor and ChangeReason store the last author and reason passed to SetChangeAuthor
+	ChangeAuthor string
+	ChangeReason string
+	// SyncedLines stores the last lines passed to SetSyncedLyrics
+	SyncedLines []*model.SyncedLyricsLine
+	// SavedTranslation stores the last translation passed to SaveTranslation
+	SavedTranslation *model.LyricsTranslation
+}
 
 ///
 
@@ -23,7 +51,10 @@ func (m *SongRepo) Create(ctx context.Context, song *model.Song) error {
 	if song.Name == "" || song.Group == "" {
 		return &dto.Error{Code: 500, Message: "internal server error", Details: "database", DebugMsg: ""}
 	}
-	song.ID = 1
+	if song.Name == ExistingSongName {
+		return dto.NewConflictError("song already exists", "mock", nil)
+	}
+	song.ID = ValidSongID
 	return nil
 }
 
@@ -32,10 +63,10 @@ func (m *SongRepo) Create(ctx context.Context, song *model.Song) error {
 func (m *SongRepo) GetSongWithDetails(ctx context.Context, group string, song string) (*dto.SongWithDetails, error) {
 
 	if group == ValidGroupName && song == ValidSongName {
-		return &dto.SongWithDetails{}, nil
+		return &dto.SongWithDetails{ID: ValidSongID, Version: ValidSongVersion}, nil
 	}
 
-	return nil, &dto.Error{Code: 400, Message: "song not found", Details: fmt.Sprintf("name=%s group=%s", song, group)}
+	return nil, dto.NewNotFoundError("song not found", "mock", fmt.Sprintf("name=%s group=%s", song, group))
 }
 
 ///
@@ -45,7 +76,7 @@ func (m *SongRepo) Delete(ctx context.Context, id int64) error {
 	fmt.Println("song id:", id)
 
 	if id != ValidSongID {
-		return &dto.Error{Code: 400, Message: "song doesn't exist"}
+		return dto.NewNotFoundError("song doesn't exist", "mock", nil)
 	}
 
 	return nil
@@ -56,39 +87,242 @@ func (m *SongRepo) Delete(ctx context.Context, id int64) error {
 func (m *SongRepo) GetSongText(ctx context.Context, id int64) (*string, error) {
 
 	if id == SongIDWithoutTextData {
-		return nil, &dto.Error{Code: 400, Message: "no info about song text", Details: fmt.Sprintf("id=%d", id)}
+		return nil, dto.NewNotFoundError("no info about song text", "mock", fmt.Sprintf("id=%d", id))
 	}
 
 	if id != ValidSongID {
-		return nil, &dto.Error{Code: 400, Message: "song not found"}
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
 	}
 
-	songText := "boundaries, key..."
+	songText := "first couplet\n\nsecond couplet"
 	return &songText, nil
 }
 
 ///
 
-func (m *SongRepo) GetSongs(ctx context.Context, aggregation map[string]any) ([]*dto.SongWithDetails, error) {
-	return nil, nil
+func (m *SongRepo) GetSongs(ctx context.Context, aggregation map[string]any) (*dto.GetSongsResponse, error) {
+	m.Aggregation = aggregation
+	return &dto.GetSongsResponse{Songs: []*dto.SongWithDetails{}}, nil
+}
+
+///
+
+// ImportSongs creates every song except the ones named ExistingSongName, which are skipped
+func (m *SongRepo) ImportSongs(ctx context.Context, songs []*model.ImportSong, dryRun bool) ([]*dto.ImportSongResult, error) {
+	m.ImportedBatches = append(m.ImportedBatches, len(songs))
+
+	results := make([]*dto.ImportSongResult, 0, len(songs))
+	for idx, song := range songs {
+		if song.Song.Name == ExistingSongName {
+			results = append(results, &dto.ImportSongResult{Row: song.Row, Status: model.ImportSkipped, Reason: "song already exists"})
+			continue
+		}
+
+		songID := int64(100 + idx)
+		results = append(results, &dto.ImportSongResult{Row: song.Row, Status: model.ImportCreated, SongID: &songID})
+	}
+	return results, nil
+}
+
+///
+
+// ExportSongs passes the ExportedSongs to the fn
+func (m *SongRepo) ExportSongs(ctx context.Context, aggregation map[string]any, fn func(song *dto.SongWithDetails) error) error {
+	m.Aggregation = aggregation
+
+	for _, song := range m.ExportedSongs {
+		if err := fn(song); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+///
+
+// GetDuplicates returns the group of the duplicates of the valid song
+func (m *SongRepo) GetDuplicates(ctx context.Context, match string, limit, offset int64) ([]*dto.SongDuplicates, error) {
+	m.DuplicatesMatch = match
+
+	return []*dto.SongDuplicates{{
+		Match: model.DuplicateNormalized,
+		Group: ValidGroupName,
+		Title: ValidSongName,
+		Songs: dto.SongList{
+			{ID: ValidSongID, Group: ValidGroupName, Title: ValidSongName},
+			{ID: LegacyDuplicateSongID, Group: ValidGroupName, Title: "song12 "},
+		},
+	}}, nil
+}
+
+///
+
+// MergeSongs merges the duplicates into the valid song, the legacy duplicate can't survive the merge
+func (m *SongRepo) MergeSongs(ctx context.Context, survivorID int64, duplicateIDs []int64) (*dto.SongWithDetails, error) {
+	if survivorID == LegacyDuplicateSongID {
+		return nil, dto.NewConflictError("song already exists", "mock", nil)
+	}
+
+	if survivorID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	return &dto.SongWithDetails{ID: ValidSongID, Group: ValidGroupName, Title: ValidSongName}, nil
 }
 
 ///
 
-func (m *SongRepo) Tx(ctx context.Context, txActions func() error) error {
-	return txActions()
+// GetSong returns the valid song with the release date, the primary link and two couplets of the text
+func (m *SongRepo) GetSong(ctx context.Context, id int64) (*dto.SongWithDetails, error) {
+	if id != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	releaseDate, link, text := "2009-09-07", "https://open.spotify.com/track/12", "first couplet\n\nsecond couplet"
+	return &dto.SongWithDetails{
+		ID:          ValidSongID,
+		Group:       ValidGroupName,
+		Title:       ValidSongName,
+		ReleaseDate: &releaseDate,
+		Link:        &link,
+		Text:        &text,
+		Version:     ValidSongVersion,
+	}, nil
+}
+
+// LockSongVersion returns the ValidSongVersion of the valid song
+func (m *SongRepo) LockSongVersion(ctx context.Context, id int64) (int64, error) {
+	if id != ValidSongID {
+		return 0, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+	return ValidSongVersion, nil
 }
 
 func (m *SongRepo) UpdateSong(ctx context.Context, song *model.Song) error {
 	if song.ID != ValidSongID {
-		return &dto.Error{Code: 400}
+		return dto.NewNotFoundError("song not found", "mock", nil)
 	}
+	m.UpdatedSong = song
 	return nil
 }
 
 func (m *SongRepo) UpdateSongDetails(ctx context.Context, details *model.SongDetail) error {
 	if details.SongID != ValidSongID {
-		return &dto.Error{Code: 400}
+		return dto.NewNotFoundError("song not found", "mock", nil)
+	}
+	m.UpdatedDetails = details
+	return nil
+}
+
+///
+
+func (m *SongRepo) SetChangeAuthor(ctx context.Context, author string, reason string) error {
+	m.ChangeAuthor, m.ChangeReason = author, reason
+	return nil
+}
+
+// GetLyricsRevisions returns the revisions of the lyrics of the valid song, the newest goes first
+func (m *SongRepo) GetLyricsRevisions(ctx context.Context, songID int64, limit int64, offset int64) ([]*dto.LyricsRevision, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	revisions := make([]*dto.LyricsRevision, 0)
+	for revision := LatestLyricsRevision - offset; revision >= 1 && int64(len(revisions)) < limit; revision-- {
+		lyricsRevision := validLyricsRevision(revision)
+		lyricsRevision.Text = nil
+		revisions = append(revisions, lyricsRevision)
+	}
+	return revisions, nil
+}
+
+// GetLyricsRevision returns the revision of the lyrics of the valid song: the first revision has the text
+// of GetSong, the second one changes its second couplet
+func (m *SongRepo) GetLyricsRevision(ctx context.Context, songID int64, revision int64) (*dto.LyricsRevision, error) {
+	if songID != ValidSongID || revision < 1 || revision > LatestLyricsRevision {
+		return nil, dto.NewNotFoundError("lyrics revision not found", "mock", nil)
+	}
+	return validLyricsRevision(revision), nil
+}
+
+func (m *SongRepo) GetLatestLyricsRevision(ctx context.Context, songID int64) (*dto.LyricsRevision, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	lyricsRevision := validLyricsRevision(LatestLyricsRevision)
+	lyricsRevision.Text = nil
+	return lyricsRevision, nil
+}
+
+func validLyricsRevision(revision int64) *dto.LyricsRevision {
+	text := "first couplet\n\nsecond couplet"
+	if revision == 2 {
+		text = "first couplet\n\nchanged couplet"
+	}
+
+	createdAt := time.Date(2024, 1, int(revision), 0, 0, 0, 0, time.UTC)
+	return &dto.LyricsRevision{Revision: revision, Author: "system", CreatedAt: createdAt, Text: &text}
+}
+
+///
+
+func (m *SongRepo) SetSyncedLyrics(ctx context.Context, songID int64, lines []*model.SyncedLyricsLine) error {
+	if songID != ValidSongID {
+		return dto.NewNotFoundError("song not found", "mock", nil)
 	}
+	m.SyncedLines = lines
 	return nil
 }
+
+// GetSyncedLyrics returns three lines of the valid song starting at 12s, 17.2s and 93s, the last one
+// has the timed words. The song without the text has no timing
+func (m *SongRepo) GetSyncedLyrics(ctx context.Context, songID int64) ([]*dto.SyncedLyricsLine, error) {
+	switch songID {
+	case ValidSongID:
+		end := func(ms int64) *int64 { return &ms }
+		return []*dto.SyncedLyricsLine{
+			{Line: 1, StartMs: 12000, EndMs: end(17200), Text: "first line"},
+			{Line: 2, StartMs: 17200, EndMs: end(93000), Text: "second line"},
+			{Line: 3, StartMs: 93000, Text: "third line", Words: dto.SyncedLyricsWords{
+				{StartMs: 93000, Text: "third"},
+				{StartMs: 93500, Text: "line"},
+			}},
+		}, nil
+	case SongIDWithoutTextData:
+		return []*dto.SyncedLyricsLine{}, nil
+	default:
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+}
+
+///
+
+func (m *SongRepo) GetTranslations(ctx context.Context, songID int64) ([]*dto.LyricsTranslation, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+	return []*dto.LyricsTranslation{validTranslation()}, nil
+}
+
+func (m *SongRepo) GetTranslation(ctx context.Context, songID int64, lang string) (*dto.LyricsTranslation, error) {
+	if songID != ValidSongID || lang != TranslationLang {
+		return nil, dto.NewNotFoundError("lyrics translation not found", "mock", nil)
+	}
+	return validTranslation(), nil
+}
+
+// SaveTranslation creates the translation unless it is the translation to the TranslationLang
+func (m *SongRepo) SaveTranslation(ctx context.Context, translation *model.LyricsTranslation) (*dto.LyricsTranslation, bool, error) {
+	if translation.SongID != ValidSongID {
+		return nil, false, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	m.SavedTranslation = translation
+	saved := &dto.LyricsTranslation{Lang: translation.Lang, Text: translation.Text, Author: translation.Author}
+	return saved, translation.Lang != TranslationLang, nil
+}
+
+func validTranslation() *dto.LyricsTranslation {
+	return &dto.LyricsTranslation{Lang: TranslationLang, Text: "first translated couplet", Author: "translator"}
+}
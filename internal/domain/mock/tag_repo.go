@@ -0,0 +1,38 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+)
+
+type TagRepo struct{}
+
+///
+
+func (m *TagRepo) GetTags(ctx context.Context, kind string, limit int64, offset int64) ([]*dto.Tag, error) {
+	return []*dto.Tag{{ID: 1, Name: "rock", Kind: "genre", UsageCount: 3}}, nil
+}
+
+///
+
+func (m *TagRepo) AttachTags(ctx context.Context, songID int64, names []string, kind string) ([]*dto.Tag, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+
+	tags := make([]*dto.Tag, 0, len(names))
+	for idx, name := range names {
+		tags = append(tags, &dto.Tag{ID: int64(idx + 1), Name: name, Kind: kind})
+	}
+	return tags, nil
+}
+
+///
+
+func (m *TagRepo) DetachTags(ctx context.Context, songID int64, names []string) ([]*dto.Tag, error) {
+	if songID != ValidSongID {
+		return nil, dto.NewNotFoundError("song not found", "mock", nil)
+	}
+	return []*dto.Tag{}, nil
+}
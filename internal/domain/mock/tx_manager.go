@@ -0,0 +1,14 @@
+package mock
+
+import "context"
+
+// TxManager executes the units of work without the transactions
+type TxManager struct {
+	// Calls is the number of the executed units of work
+	Calls int
+}
+
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
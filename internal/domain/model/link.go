@@ -0,0 +1,75 @@
+package model
+
+import (
+	"net/url"
+	"strings"
+)
+
+// streaming platforms of the song links
+const (
+	PlatformSpotify    = "spotify"
+	PlatformApple      = "apple"
+	PlatformSoundCloud = "soundcloud"
+	PlatformYandex     = "yandex"
+	PlatformYouTube    = "youtube"
+	PlatformOther      = "other"
+)
+
+type SongLink struct {
+	ID       int64
+	SongID   int64
+	Platform string
+	URL      string
+	Primary  bool
+}
+
+// platformHosts maps the hosts (and their subdomains) to the streaming platforms
+var platformHosts = map[string]string{
+	"spotify.com":      PlatformSpotify,
+	"spoti.fi":         PlatformSpotify,
+	"music.apple.com":  PlatformApple,
+	"itunes.apple.com": PlatformApple,
+	"soundcloud.com":   PlatformSoundCloud,
+	"snd.sc":           PlatformSoundCloud,
+	"music.yandex.ru":  PlatformYandex,
+	"music.yandex.com": PlatformYandex,
+	"music.yandex.by":  PlatformYandex,
+	"music.yandex.kz":  PlatformYandex,
+	"youtube.com":      PlatformYouTube,
+	"youtu.be":         PlatformYouTube,
+}
+
+// DetectPlatform returns the streaming platform of the link by its host,
+// links without a scheme are accepted. Unknown hosts give PlatformOther
+func DetectPlatform(link string) string {
+	host := LinkHost(link)
+
+	for host != "" {
+		if platform, ok := platformHosts[host]; ok {
+			return platform
+		}
+
+		//try the parent domain [music.youtube.com -> youtube.com]
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			break
+		}
+		host = parent
+	}
+
+	return PlatformOther
+}
+
+// LinkHost returns the lowercased host of the link, an empty string if the link can't be parsed
+func LinkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
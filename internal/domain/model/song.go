@@ -1,17 +1,96 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type Song struct {
-	ID    int64
-	Name  string
-	Group string
+	ID       int64
+	ArtistID int64
+	Name     string
+	Group    string
 }
 
+// duplicate matches of the songs
+const (
+	DuplicateExact      = "exact"
+	DuplicateNormalized = "normalized"
+)
+
+// NormalizeSongName folds the case and the spaces of the name the same way as the song_name_normalize
+// function of the database, the songs of the artist can't have the same normalized names
+func NormalizeSongName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+type Artist struct {
+	ID   int64
+	Name string
+}
+
+// fields of the song details which can be cleared by the update
+const (
+	SongDetailReleaseDate = "release_date"
+	SongDetailText        = "text"
+	SongDetailLink        = "link"
+)
+
 type SongDetail struct {
 	ID          int64
 	SongID      int64
 	ReleaseDate *time.Time
 	Text        *string
 	Link        *string
+	// Clear lists the fields which are set to null by the update
+	Clear []string
+}
+
+type EnrichmentJob struct {
+	SongID   int64
+	Group    string
+	Song     string
+	Attempts int
+}
+
+type Album struct {
+	ID          int64
+	Title       string
+	ReleaseDate *time.Time
+	CoverLink   *string
+}
+
+// import statuses of the rows
+const (
+	ImportCreated = "created"
+	ImportSkipped = "skipped"
+	ImportFailed  = "failed"
+)
+
+// ImportSong is the song with its details of the import file, Row is the number of the row in the file
+type ImportSong struct {
+	Row     int
+	Song    Song
+	Details SongDetail
+}
+
+// SyncedLyricsLine is the timed line of the lyrics, it starts StartMs milliseconds from the beginning of the song.
+// Words are the timed words of the enhanced LRC
+type SyncedLyricsLine struct {
+	StartMs int64
+	Text    string
+	Words   []SyncedLyricsWord
+}
+
+type SyncedLyricsWord struct {
+	StartMs int64
+	Text    string
+}
+
+// LyricsTranslation is the translation of the lyrics of the song, Lang is the canonical BCP 47 tag
+type LyricsTranslation struct {
+	SongID int64
+	Lang   string
+	Text   string
+	Author string
 }
@@ -0,0 +1,8 @@
+package model
+
+// SortField describes one key of the list order
+type SortField struct {
+	Field      string
+	Desc       bool
+	NullsFirst bool
+}
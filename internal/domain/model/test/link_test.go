@@ -0,0 +1,34 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPlatform(t *testing.T) {
+	testCases := []struct {
+		Link     string
+		Platform string
+	}{
+		{"https://open.spotify.com/track/12", model.PlatformSpotify},
+		{"spoti.fi/3abc", model.PlatformSpotify},
+		{"https://music.apple.com/us/album/12", model.PlatformApple},
+		{"https://www.apple.com/music", model.PlatformOther},
+		{"http://SoundCloud.com/queen/track", model.PlatformSoundCloud},
+		{"https://music.yandex.ru/album/1/track/2", model.PlatformYandex},
+		{"https://yandex.ru/search", model.PlatformOther},
+		{"https://www.youtube.com/watch?v=12", model.PlatformYouTube},
+		{"https://music.youtube.com/watch?v=12", model.PlatformYouTube},
+		{"youtu.be/12", model.PlatformYouTube},
+		{"https://notspotify.com/track/12", model.PlatformOther},
+		{"", model.PlatformOther},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Link, func(t *testing.T) {
+			assert.Equal(t, tc.Platform, model.DetectPlatform(tc.Link))
+		})
+	}
+}
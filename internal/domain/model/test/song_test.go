@@ -0,0 +1,27 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSongName(t *testing.T) {
+	testCases := []struct {
+		Name       string
+		Normalized string
+	}{
+		{"Bohemian Rhapsody", "bohemian rhapsody"},
+		{"  Bohemian   Rhapsody ", "bohemian rhapsody"},
+		{"BOHEMIAN\tRHAPSODY", "bohemian rhapsody"},
+		{"Кукушка", "кукушка"},
+		{"", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Normalized, model.NormalizeSongName(tc.Name))
+		})
+	}
+}
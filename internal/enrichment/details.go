@@ -0,0 +1,32 @@
+package enrichment
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/client/songinfo"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// songDetailFromInfo converts the provider response to the song details model, empty values are left nil
+func songDetailFromInfo(songID int64, info *songinfo.SongDetail) (*model.SongDetail, error) {
+	details := &model.SongDetail{SongID: songID}
+
+	if info.ReleaseDate != "" {
+		releaseDate, err := time.Parse("02.01.2006", info.ReleaseDate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid release date in song info, release_date=%s", info.ReleaseDate)
+		}
+		details.ReleaseDate = &releaseDate
+	}
+
+	if info.Text != "" {
+		details.Text = &info.Text
+	}
+
+	if info.Link != "" {
+		details.Link = &info.Link
+	}
+
+	return details, nil
+}
@@ -0,0 +1,216 @@
+package enrichment
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/amicie-monami/music-library/config"
+	"github.com/amicie-monami/music-library/internal/client/songinfo"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+type jobStore interface {
+	EnqueueEmpty(ctx context.Context) (int64, error)
+	Claim(ctx context.Context, limit int) ([]*model.EnrichmentJob, error)
+	Complete(ctx context.Context, songID int64) error
+	Fail(ctx context.Context, songID int64, reason string, nextAttemptAt *time.Time) error
+	Release(ctx context.Context, songID int64) error
+	ResetRunning(ctx context.Context, lease time.Duration) (int64, error)
+}
+
+type songDetailsUpdater interface {
+	UpdateSongDetails(ctx context.Context, details *model.SongDetail) error
+}
+
+type songInfoProvider interface {
+	GetInfo(ctx context.Context, group string, song string) (*songinfo.SongDetail, error)
+}
+
+// Pool is a set of background workers which enrich the empty song details by the song info provider.
+// The dispatcher polls the job store and passes the claimed jobs to the workers
+type Pool struct {
+	config   config.EnrichmentConfig
+	store    jobStore
+	songs    songDetailsUpdater
+	provider songInfoProvider
+
+	jobs   chan *model.EnrichmentJob
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(config config.EnrichmentConfig, store jobStore, songs songDetailsUpdater, provider songInfoProvider) *Pool {
+	return &Pool{config: config, store: store, songs: songs, provider: provider}
+}
+
+// Start runs the dispatcher and the workers in the background
+func (p *Pool) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.jobs = make(chan *model.EnrichmentJob)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(p.jobs)
+		p.dispatch(ctx)
+	}()
+
+	for idx := 0; idx < p.config.Workers; idx++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for job := range p.jobs {
+				p.process(ctx, job)
+			}
+		}()
+	}
+}
+
+// Shutdown stops the dispatcher and waits for the workers to finish the current jobs
+func (p *Pool) Shutdown(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// dispatch polls the store for the due jobs until the context is canceled
+func (p *Pool) dispatch(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(p.config.PollInterval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		p.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Pool) poll(ctx context.Context) {
+	//jobs stay running if their instance has been killed, they are returned to the queue when the lease expires
+	lease := time.Duration(p.config.JobLease) * time.Second
+	if count, err := p.store.ResetRunning(ctx, lease); err != nil && ctx.Err() == nil {
+		slog.Error("enrichment reset running jobs", "err", err.Error())
+	} else if count > 0 {
+		slog.Info("enrichment jobs have been returned to the queue", "count", count)
+	}
+
+	if _, err := p.store.EnqueueEmpty(ctx); err != nil && ctx.Err() == nil {
+		slog.Error("enrichment enqueue", "err", err.Error())
+		return
+	}
+
+	jobs, err := p.store.Claim(ctx, p.config.BatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("enrichment claim", "err", err.Error())
+		}
+		return
+	}
+
+	for idx, job := range jobs {
+		select {
+		case p.jobs <- job:
+		case <-ctx.Done():
+			//return the claimed but not started jobs to the queue
+			for _, job := range jobs[idx:] {
+				p.release(ctx, job)
+			}
+			return
+		}
+	}
+}
+
+// process enriches the song details and records the result of the attempt
+func (p *Pool) process(ctx context.Context, job *model.EnrichmentJob) {
+	slog.Debug("enrich song details", "song_id", job.SongID, "attempt", job.Attempts)
+
+	err := p.enrich(ctx, job)
+
+	//the result is stored even if the shutdown has begun during the attempt
+	storeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err == nil {
+		if err := p.store.Complete(storeCtx, job.SongID); err != nil {
+			slog.Error("enrichment complete", "song_id", job.SongID, "err", err.Error())
+			return
+		}
+		slog.Info("song details have been enriched", "song_id", job.SongID)
+		return
+	}
+
+	//the attempt has been interrupted by the shutdown, it doesn't count
+	if ctx.Err() != nil {
+		p.release(ctx, job)
+		return
+	}
+
+	var nextAttemptAt *time.Time
+	if !errors.Is(err, songinfo.ErrNotFound) && job.Attempts < p.config.MaxAttempts {
+		next := time.Now().Add(p.backoff(job.Attempts))
+		nextAttemptAt = &next
+	}
+
+	slog.Warn("failed to enrich song details", "song_id", job.SongID, "attempt", job.Attempts, "err", err.Error())
+	if err := p.store.Fail(storeCtx, job.SongID, err.Error(), nextAttemptAt); err != nil {
+		slog.Error("enrichment fail", "song_id", job.SongID, "err", err.Error())
+	}
+}
+
+func (p *Pool) enrich(ctx context.Context, job *model.EnrichmentJob) error {
+	info, err := p.provider.GetInfo(ctx, job.Group, job.Song)
+	if err != nil {
+		return err
+	}
+
+	details, err := songDetailFromInfo(job.SongID, info)
+	if err != nil {
+		return err
+	}
+
+	return p.songs.UpdateSongDetails(ctx, details)
+}
+
+// release returns the job to the queue, the store is called with
+// a detached context because the pool context is already canceled
+func (p *Pool) release(ctx context.Context, job *model.EnrichmentJob) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+
+	if err := p.store.Release(ctx, job.SongID); err != nil {
+		slog.Error("enrichment release", "song_id", job.SongID, "err", err.Error())
+	}
+}
+
+// backoff returns the exponential delay before the next attempt, limited by the MaxBackoff
+func (p *Pool) backoff(attempts int) time.Duration {
+	delay := time.Duration(p.config.Backoff) * time.Second
+	maxDelay := time.Duration(p.config.MaxBackoff) * time.Second
+
+	for idx := 1; idx < attempts && delay < maxDelay; idx++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
@@ -0,0 +1,91 @@
+package enrichment_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/amicie-monami/music-library/config"
+	"github.com/amicie-monami/music-library/internal/client/songinfo"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/enrichment"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Code        int
+		Completed   bool
+		Rescheduled bool
+	}{
+		{
+			Description: "Provider responds with song info",
+			Code:        http.StatusOK,
+			Completed:   true,
+		},
+		{
+			Description: "Provider doesn't know the song",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Provider is unavailable",
+			Code:        http.StatusServiceUnavailable,
+			Rescheduled: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/info", r.URL.Path)
+				assert.Equal(t, mock.ValidGroupName, r.URL.Query().Get("group"))
+				assert.Equal(t, mock.ValidSongName, r.URL.Query().Get("song"))
+
+				w.WriteHeader(tc.Code)
+				if tc.Code == http.StatusOK {
+					json.NewEncoder(w).Encode(songinfo.SongDetail{
+						ReleaseDate: "16.07.2006",
+						Text:        "Ooh baby, don't you know I suffer?\\n\\nOoh baby, can you hear me moan?",
+						Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+					})
+				}
+			}))
+			defer provider.Close()
+
+			store := &mock.EnrichmentRepo{
+				Queue: []*model.EnrichmentJob{{SongID: mock.ValidSongID, Group: mock.ValidGroupName, Song: mock.ValidSongName, Attempts: 1}},
+			}
+			songs := &mock.SongRepo{}
+			client := songinfo.New(config.SongInfoConfig{URL: provider.URL, Timeout: 1})
+			enrichmentConfig := config.EnrichmentConfig{Workers: 2, BatchSize: 10, PollInterval: 1, MaxAttempts: 3, Backoff: 1, MaxBackoff: 10}
+
+			pool := enrichment.New(enrichmentConfig, store, songs, client)
+			pool.Start()
+
+			assert.Eventually(t, func() bool { return store.Processed() == 1 }, 2*time.Second, 10*time.Millisecond)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			assert.NoError(t, pool.Shutdown(ctx))
+
+			if tc.Completed {
+				assert.Equal(t, []int64{mock.ValidSongID}, store.Completed)
+				if assert.NotNil(t, songs.UpdatedDetails) {
+					assert.Equal(t, "2006-07-16", songs.UpdatedDetails.ReleaseDate.Format("2006-01-02"))
+					assert.Equal(t, "https://www.youtube.com/watch?v=Xsp3_a-PMTw", *songs.UpdatedDetails.Link)
+				}
+				return
+			}
+
+			nextAttemptAt, ok := store.Failed[mock.ValidSongID]
+			assert.True(t, ok)
+			assert.Equal(t, tc.Rescheduled, nextAttemptAt != nil)
+			assert.Nil(t, songs.UpdatedDetails)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package filter
+
+// Node is a node of the filter tree: And, Or, Not or Comparison
+type Node interface {
+	node()
+}
+
+// And matches the rows which match every node
+type And struct {
+	Nodes []Node
+}
+
+// Or matches the rows which match any node
+type Or struct {
+	Nodes []Node
+}
+
+// Not matches the rows which don't match the node
+type Not struct {
+	Node Node
+}
+
+// Comparison compares the field with the values [e.g. release_date=ge=1970-01-01].
+// Args are the raw values of the query, Values are the values converted to the field type by the schema
+type Comparison struct {
+	Field  string
+	Op     Op
+	Args   []string
+	Values []any
+	// Pos is the position of the comparison in the query, it's used in the error messages
+	Pos int
+}
+
+func (*And) node()        {}
+func (*Or) node()         {}
+func (*Not) node()        {}
+func (*Comparison) node() {}
+
+// Walk calls the fn for every comparison of the tree
+func Walk(node Node, fn func(c *Comparison)) {
+	switch node := node.(type) {
+	case *And:
+		for _, child := range node.Nodes {
+			Walk(child, fn)
+		}
+	case *Or:
+		for _, child := range node.Nodes {
+			Walk(child, fn)
+		}
+	case *Not:
+		Walk(node.Node, fn)
+	case *Comparison:
+		fn(node)
+	}
+}
+
+// HasOp reports whether the tree has a comparison with the op
+func HasOp(node Node, op Op) bool {
+	found := false
+	Walk(node, func(c *Comparison) {
+		found = found || c.Op == op
+	})
+	return found
+}
@@ -0,0 +1,150 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ParseJSON parses the JSON filter document and checks it by the schema. The node of the document is one of:
+//
+//	{"and": [node, ...]}
+//	{"or": [node, ...]}
+//	{"not": node}
+//	{"field": "group", "op": "in", "value": ["ping", "pong"]}
+//
+// The ops are named like the query ones without the "=" signs [e.g. ge, in, fuzzy]. The value is a string,
+// a number, a bool or a list of them. The errors have the path to the wrong node [e.g. filter.and[1].op]
+func ParseJSON(data []byte, schema Schema) (Node, error) {
+	node, err := parseJSONNode(data, schema, "filter", 0)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func parseJSONNode(data []byte, schema Schema, path string, depth int) (Node, *Error) {
+	if depth >= maxDepth {
+		return nil, pathError(path, fmt.Sprintf("the filter is nested too deep, max depth is %d", maxDepth))
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil || object == nil {
+		return nil, pathError(path, "expected an object")
+	}
+
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	switch strings.Join(keys, ",") {
+	case "and", "or":
+		key := keys[0]
+
+		var rawNodes []json.RawMessage
+		if err := json.Unmarshal(object[key], &rawNodes); err != nil || len(rawNodes) == 0 {
+			return nil, pathError(path+"."+key, "expected a non-empty list of nodes")
+		}
+
+		nodes := make([]Node, 0, len(rawNodes))
+		for idx, rawNode := range rawNodes {
+			node, err := parseJSONNode(rawNode, schema, fmt.Sprintf("%s.%s[%d]", path, key, idx), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+
+		if key == "and" {
+			return &And{Nodes: nodes}, nil
+		}
+		return &Or{Nodes: nodes}, nil
+
+	case "not":
+		node, err := parseJSONNode(object["not"], schema, path+".not", depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node}, nil
+
+	case "field,op,value":
+		return parseJSONComparison(object, schema, path)
+
+	default:
+		return nil, pathError(path, fmt.Sprintf("unexpected keys [%s], expected one of [and], [or], [not] or [field, op, value]", strings.Join(keys, ", ")))
+	}
+}
+
+func parseJSONComparison(object map[string]json.RawMessage, schema Schema, path string) (Node, *Error) {
+	var field, opName string
+	if err := json.Unmarshal(object["field"], &field); err != nil {
+		return nil, pathError(path+".field", "expected a string")
+	}
+
+	if err := json.Unmarshal(object["op"], &opName); err != nil {
+		return nil, pathError(path+".op", "expected a string")
+	}
+
+	op, ok := ParseOp(opName)
+	if !ok {
+		return nil, pathError(path+".op", fmt.Sprintf("unknown operator '%s'", opName))
+	}
+
+	args, err := parseJSONValue(object["value"], path+".value")
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{Field: field, Op: op, Args: args}
+	if err := schema.checkComparison(comparison); err != nil {
+		err.Path = path
+		return nil, err
+	}
+
+	return comparison, nil
+}
+
+// parseJSONValue converts the scalar or the list of scalars to the raw values
+func parseJSONValue(data json.RawMessage, path string) ([]string, *Error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, pathError(path, "invalid value")
+	}
+
+	list, isList := value.([]any)
+	if !isList {
+		list = []any{value}
+	}
+
+	args := make([]string, 0, len(list))
+	for idx, item := range list {
+		switch item := item.(type) {
+		case string:
+			args = append(args, item)
+		case json.Number:
+			args = append(args, item.String())
+		case bool:
+			args = append(args, strconv.FormatBool(item))
+		default:
+			itemPath := path
+			if isList {
+				itemPath = fmt.Sprintf("%s[%d]", path, idx)
+			}
+			return nil, pathError(itemPath, "expected a string, a number or a bool")
+		}
+	}
+
+	return args, nil
+}
+
+func pathError(path string, message string) *Error {
+	return &Error{Path: path, Message: message}
+}
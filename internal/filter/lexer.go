@@ -0,0 +1,199 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOperator
+	tokenValue
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokenEOF:
+		return "end of the filter"
+	case tokenLParen:
+		return "'('"
+	case tokenRParen:
+		return "')'"
+	case tokenAnd:
+		return "';'"
+	case tokenOr:
+		return "','"
+	case tokenNot:
+		return "'!'"
+	case tokenOperator:
+		return "operator"
+	default:
+		return "value"
+	}
+}
+
+type token struct {
+	kind  tokenKind
+	value string
+	// pos is the position of the first character of the token, starting from 1
+	pos int
+}
+
+// reservedChars can't be used in the unquoted values
+const reservedChars = `"'();,=!~<>`
+
+// lexer splits the filter query into the tokens
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: []rune(input)}
+}
+
+// tokenize returns all tokens of the query, the last one is tokenEOF
+func (l *lexer) tokenize() ([]token, error) {
+	tokens := make([]token, 0)
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, tok)
+		if tok.kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+
+	start := l.pos + 1
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+
+	switch char := l.input[l.pos]; char {
+	case '(':
+		l.pos++
+		return token{kind: tokenLParen, value: "(", pos: start}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokenRParen, value: ")", pos: start}, nil
+	case ';':
+		l.pos++
+		return token{kind: tokenAnd, value: ";", pos: start}, nil
+	case ',':
+		l.pos++
+		return token{kind: tokenOr, value: ",", pos: start}, nil
+	case '!':
+		if l.peek(1) == '=' {
+			l.pos += 2
+			return token{kind: tokenOperator, value: "!=", pos: start}, nil
+		}
+		l.pos++
+		return token{kind: tokenNot, value: "!", pos: start}, nil
+	case '<', '>', '~':
+		return l.comparisonOperator(start)
+	case '=':
+		return l.namedOperator(start)
+	case '"', '\'':
+		return l.quotedValue(start)
+	default:
+		return l.value(start), nil
+	}
+}
+
+// comparisonOperator reads one of the operators [<, <=, >, >=, ~=]
+func (l *lexer) comparisonOperator(start int) (token, error) {
+	char := l.input[l.pos]
+	if l.peek(1) == '=' {
+		l.pos += 2
+		return token{kind: tokenOperator, value: string(char) + "=", pos: start}, nil
+	}
+
+	if char == '~' {
+		return token{}, newError(start, "unknown operator '~', did you mean '~='?")
+	}
+
+	l.pos++
+	return token{kind: tokenOperator, value: string(char), pos: start}, nil
+}
+
+// namedOperator reads the "==" operator or the named operator [e.g. =ge=]
+func (l *lexer) namedOperator(start int) (token, error) {
+	if l.peek(1) == '=' {
+		l.pos += 2
+		return token{kind: tokenOperator, value: "==", pos: start}, nil
+	}
+
+	end := l.pos + 1
+	for end < len(l.input) && unicode.IsLetter(l.input[end]) {
+		end++
+	}
+
+	if end == l.pos+1 || end >= len(l.input) || l.input[end] != '=' {
+		return token{}, newError(start, "incomplete operator, expected '==' or the named operator like '=in='")
+	}
+
+	value := string(l.input[l.pos : end+1])
+	l.pos = end + 1
+	return token{kind: tokenOperator, value: value, pos: start}, nil
+}
+
+// quotedValue reads the value in single or double quotes, the backslash escapes the next character
+func (l *lexer) quotedValue(start int) (token, error) {
+	quote := l.input[l.pos]
+	l.pos++
+
+	var value strings.Builder
+	for l.pos < len(l.input) {
+		char := l.input[l.pos]
+		l.pos++
+
+		switch {
+		case char == '\\' && l.pos < len(l.input):
+			value.WriteRune(l.input[l.pos])
+			l.pos++
+		case char == quote:
+			return token{kind: tokenValue, value: value.String(), pos: start}, nil
+		default:
+			value.WriteRune(char)
+		}
+	}
+
+	return token{}, newError(start, fmt.Sprintf("unterminated quoted value, expected closing %c", quote))
+}
+
+// value reads the unquoted value or the field name
+func (l *lexer) value(start int) token {
+	begin := l.pos
+	for l.pos < len(l.input) {
+		char := l.input[l.pos]
+		if unicode.IsSpace(char) || strings.ContainsRune(reservedChars, char) {
+			break
+		}
+		l.pos++
+	}
+	return token{kind: tokenValue, value: string(l.input[begin:l.pos]), pos: start}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset < len(l.input) {
+		return l.input[l.pos+offset]
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package filter
+
+// Op is the comparison operator
+type Op string
+
+const (
+	OpEq    Op = "eq"
+	OpNe    Op = "ne"
+	OpLt    Op = "lt"
+	OpLe    Op = "le"
+	OpGt    Op = "gt"
+	OpGe    Op = "ge"
+	OpIn    Op = "in"
+	OpOut   Op = "out"
+	OpLike  Op = "like"
+	OpFuzzy Op = "fuzzy"
+	OpAll   Op = "all"
+	OpNull  Op = "null"
+)
+
+// queryOps maps the operators of the query syntax to the ops
+var queryOps = map[string]Op{
+	"==":      OpEq,
+	"!=":      OpNe,
+	"<":       OpLt,
+	"<=":      OpLe,
+	">":       OpGt,
+	">=":      OpGe,
+	"~=":      OpFuzzy,
+	"=eq=":    OpEq,
+	"=ne=":    OpNe,
+	"=lt=":    OpLt,
+	"=le=":    OpLe,
+	"=gt=":    OpGt,
+	"=ge=":    OpGe,
+	"=in=":    OpIn,
+	"=out=":   OpOut,
+	"=like=":  OpLike,
+	"=fuzzy=": OpFuzzy,
+	"=all=":   OpAll,
+	"=null=":  OpNull,
+}
+
+// ParseOp returns the op by its name [e.g. "ge"], it's used by the JSON filters
+func ParseOp(name string) (Op, bool) {
+	op := Op(name)
+	switch op {
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpIn, OpOut, OpLike, OpFuzzy, OpAll, OpNull:
+		return op, true
+	}
+	return "", false
+}
+
+// isListOp reports whether the op takes a list of values
+func isListOp(op Op) bool {
+	return op == OpIn || op == OpOut || op == OpAll
+}
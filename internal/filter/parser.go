@@ -0,0 +1,244 @@
+package filter
+
+import (
+	"fmt"
+)
+
+// maxDepth limits the nesting of the groups and negations
+const maxDepth = 32
+
+// Error is the error of the filter with the position of the wrong token
+type Error struct {
+	// Pos is the position of the wrong token, starting from 1. Zero if the error isn't bound to a position
+	Pos int
+	// Path is the path to the wrong node of the JSON filter [e.g. filter.and[1].not]
+	Path    string
+	Message string
+}
+
+func newError(pos int, message string) *Error {
+	return &Error{Pos: pos, Message: message}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Path != "":
+		return fmt.Sprintf("%s: %s", e.Path, e.Message)
+	case e.Pos != 0:
+		return fmt.Sprintf("position %d: %s", e.Pos, e.Message)
+	default:
+		return e.Message
+	}
+}
+
+// Parse parses the filter query and checks it by the schema. The grammar is:
+//
+//	or         = and { "," and }
+//	and        = unary { ";" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = field operator ( value | "(" value { "," value } ")" )
+//
+// Operators are ==, !=, <, <=, >, >=, ~= and the named ones: =eq=, =ne=, =lt=, =le=, =gt=, =ge=,
+// =in=, =out=, =like=, =fuzzy=, =all=, =null=. The values with spaces or reserved characters are quoted
+// [e.g. group=="Pink Floyd";(release_date=ge=1970-01-01,text=like=*love*)]
+func Parse(query string, schema Schema) (Node, error) {
+	tokens, err := newLexer(query).tokenize()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	node, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok, "';', ',' or the end of the filter")
+	}
+
+	if err := schema.Check(node); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr(depth int) (Node, error) {
+	node, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{node}
+	for p.peek().kind == tokenOr {
+		p.advance()
+
+		node, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &Or{Nodes: nodes}, nil
+}
+
+func (p *parser) parseAnd(depth int) (Node, error) {
+	node, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []Node{node}
+	for p.peek().kind == tokenAnd {
+		p.advance()
+
+		node, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return &And{Nodes: nodes}, nil
+}
+
+func (p *parser) parseUnary(depth int) (Node, error) {
+	tok := p.peek()
+	if depth >= maxDepth {
+		return nil, newError(tok.pos, fmt.Sprintf("the filter is nested too deep, max depth is %d", maxDepth))
+	}
+
+	switch tok.kind {
+	case tokenNot:
+		p.advance()
+
+		node, err := p.parseUnary(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Node: node}, nil
+
+	case tokenLParen:
+		p.advance()
+
+		node, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.advance(); closing.kind != tokenRParen {
+			return nil, p.unexpected(closing, fmt.Sprintf("')' to close '(' at position %d", tok.pos))
+		}
+		return node, nil
+
+	case tokenValue:
+		return p.parseComparison()
+
+	default:
+		return nil, p.unexpected(tok, "a field, '(' or '!'")
+	}
+}
+
+func (p *parser) parseComparison() (Node, error) {
+	field := p.advance()
+
+	opToken := p.advance()
+	if opToken.kind != tokenOperator {
+		return nil, p.unexpected(opToken, fmt.Sprintf("an operator after the field '%s'", field.value))
+	}
+
+	op, ok := queryOps[opToken.value]
+	if !ok {
+		return nil, newError(opToken.pos, fmt.Sprintf("unknown operator '%s'", opToken.value))
+	}
+
+	args, err := p.parseArgs()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comparison{Field: field.value, Op: op, Args: args, Pos: field.pos}, nil
+}
+
+// parseArgs parses the single value or the list of values in parentheses
+func (p *parser) parseArgs() ([]string, error) {
+	tok := p.advance()
+
+	if tok.kind == tokenValue {
+		return []string{tok.value}, nil
+	}
+
+	if tok.kind != tokenLParen {
+		return nil, p.unexpected(tok, "a value or a list of values in parentheses")
+	}
+
+	args := make([]string, 0)
+	for {
+		value := p.advance()
+		if value.kind != tokenValue {
+			return nil, p.unexpected(value, "a value")
+		}
+		args = append(args, value.value)
+
+		switch next := p.advance(); next.kind {
+		case tokenOr:
+			continue
+		case tokenRParen:
+			return args, nil
+		default:
+			return nil, p.unexpected(next, fmt.Sprintf("',' or ')' to close the list at position %d", tok.pos))
+		}
+	}
+}
+
+func (p *parser) unexpected(tok token, expected string) *Error {
+	if tok.kind == tokenEOF {
+		return newError(tok.pos, fmt.Sprintf("unexpected end of the filter, expected %s", expected))
+	}
+
+	found := tok.kind.String()
+	if tok.kind == tokenValue || tok.kind == tokenOperator {
+		found = fmt.Sprintf("%s '%s'", tok.kind, tok.value)
+	}
+
+	message := fmt.Sprintf("unexpected %s, expected %s", found, expected)
+	if tok.kind == tokenValue && p.followsValue(tok) {
+		message += ", quote the values with spaces"
+	}
+	return newError(tok.pos, message)
+}
+
+// followsValue reports whether the token goes right after the value, it's the case of the unquoted value with spaces
+func (p *parser) followsValue(tok token) bool {
+	for idx := 1; idx < len(p.tokens); idx++ {
+		if p.tokens[idx].pos == tok.pos {
+			return p.tokens[idx-1].kind == tokenValue
+		}
+	}
+	return false
+}
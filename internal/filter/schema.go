@@ -0,0 +1,145 @@
+package filter
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueType is the type of the field values
+type ValueType int
+
+const (
+	TypeString ValueType = iota
+	TypeInt
+	TypeDate
+)
+
+// dateLayouts are the supported formats of the date values
+var dateLayouts = []string{"2006-01-02", "02.01.2006"}
+
+// Field describes the filter field: the type of its values and the allowed ops
+type Field struct {
+	Type ValueType
+	Ops  []Op
+}
+
+// Schema maps the field names to their descriptions
+type Schema map[string]Field
+
+// comparisonOps are the ops of the ordered values
+var comparisonOps = []Op{OpEq, OpNe, OpLt, OpLe, OpGt, OpGe, OpIn, OpOut}
+
+// Songs is the schema of the songs list filter
+var Songs = Schema{
+	"song_id":      {Type: TypeInt, Ops: comparisonOps},
+	"artist_id":    {Type: TypeInt, Ops: comparisonOps},
+	"group":        {Type: TypeString, Ops: []Op{OpEq, OpNe, OpIn, OpOut, OpLike, OpFuzzy}},
+	"song":         {Type: TypeString, Ops: []Op{OpEq, OpNe, OpIn, OpOut, OpLike, OpFuzzy}},
+	"release_date": {Type: TypeDate, Ops: append(slices.Clone(comparisonOps), OpNull)},
+	"text":         {Type: TypeString, Ops: []Op{OpLike, OpNull}},
+	"link":         {Type: TypeString, Ops: []Op{OpEq, OpLike, OpNull}},
+	"album":        {Type: TypeInt, Ops: []Op{OpEq, OpIn, OpOut}},
+	"tags":         {Type: TypeString, Ops: []Op{OpEq, OpIn, OpAll, OpOut}},
+}
+
+// Check checks the fields, ops and the number of values of every comparison of the tree
+// and converts the raw values to the field types
+func (s Schema) Check(node Node) error {
+	var err *Error
+	Walk(node, func(c *Comparison) {
+		if err == nil {
+			err = s.checkComparison(c)
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s Schema) checkComparison(c *Comparison) *Error {
+	field, ok := s[c.Field]
+	if !ok {
+		return newError(c.Pos, fmt.Sprintf("unknown field '%s', expected one of [%s]", c.Field, strings.Join(s.fieldNames(), ", ")))
+	}
+
+	if !slices.Contains(field.Ops, c.Op) {
+		return newError(c.Pos, fmt.Sprintf("operator '%s' isn't supported by the field '%s', expected one of [%s]", c.Op, c.Field, joinOps(field.Ops)))
+	}
+
+	if isListOp(c.Op) && len(c.Args) == 0 {
+		return newError(c.Pos, fmt.Sprintf("operator '%s' of the field '%s' expects a list of values", c.Op, c.Field))
+	}
+
+	if !isListOp(c.Op) && len(c.Args) != 1 {
+		return newError(c.Pos, fmt.Sprintf("operator '%s' of the field '%s' expects a single value", c.Op, c.Field))
+	}
+
+	values := make([]any, 0, len(c.Args))
+	for _, arg := range c.Args {
+		value, err := convertValue(field.Type, c.Op, arg)
+		if err != nil {
+			return newError(c.Pos, fmt.Sprintf("invalid value '%s' of the field '%s': %s", arg, c.Field, err))
+		}
+		values = append(values, value)
+	}
+
+	c.Values = values
+	return nil
+}
+
+// convertValue converts the raw value to the type, the value of the null op is a bool
+func convertValue(valueType ValueType, op Op, arg string) (any, error) {
+	if op == OpNull {
+		value, err := strconv.ParseBool(arg)
+		if err != nil {
+			return nil, fmt.Errorf("expected true or false")
+		}
+		return value, nil
+	}
+
+	if arg == "" {
+		return nil, fmt.Errorf("the value is empty")
+	}
+
+	switch valueType {
+	case TypeInt:
+		value, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expected an integer")
+		}
+		return value, nil
+
+	case TypeDate:
+		for _, layout := range dateLayouts {
+			if date, err := time.Parse(layout, arg); err == nil {
+				return date, nil
+			}
+		}
+		return nil, fmt.Errorf("expected a date in yyyy-mm-dd or dd.mm.yyyy format")
+
+	default:
+		return arg, nil
+	}
+}
+
+func (s Schema) fieldNames() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func joinOps(ops []Op) string {
+	names := make([]string, 0, len(ops))
+	for _, op := range ops {
+		names = append(names, string(op))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,63 @@
+package filter_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJSON(t *testing.T) {
+	data := `{"and": [
+		{"field": "song_id", "op": "ge", "value": 12},
+		{"not": {"or": [{"field": "link", "op": "null", "value": true}, {"field": "group", "op": "eq", "value": "ping"}]}}
+	]}`
+
+	node, err := filter.ParseJSON([]byte(data), filter.Songs)
+	require.NoError(t, err)
+
+	expected := &filter.And{Nodes: []filter.Node{
+		&filter.Comparison{Field: "song_id", Op: filter.OpGe, Args: []string{"12"}, Values: []any{int64(12)}},
+		&filter.Not{Node: &filter.Or{Nodes: []filter.Node{
+			&filter.Comparison{Field: "link", Op: filter.OpNull, Args: []string{"true"}, Values: []any{true}},
+			&filter.Comparison{Field: "group", Op: filter.OpEq, Args: []string{"ping"}, Values: []any{"ping"}},
+		}}},
+	}}
+
+	assert.Equal(t, expected, node)
+}
+
+func TestParseJSONErrors(t *testing.T) {
+	testCases := []struct {
+		Data    string
+		Path    string
+		Message string
+	}{
+		{`[]`, "filter", "expected an object"},
+		{`{"and": []}`, "filter.and", "expected a non-empty list"},
+		{`{"and": [{"field": "group"}]}`, "filter.and[0]", "unexpected keys [field]"},
+		{`{"or": [{"not": {"field": "group", "op": "like", "value": "*a*", "extra": 1}}]}`, "filter.or[0].not", "unexpected keys"},
+		{`{"field": 1, "op": "eq", "value": "a"}`, "filter.field", "expected a string"},
+		{`{"field": "group", "op": "contains", "value": "a"}`, "filter.op", "unknown operator 'contains'"},
+		{`{"field": "album", "op": "in", "value": [1, {"id": 2}]}`, "filter.value[1]", "expected a string, a number or a bool"},
+		{`{"field": "group", "op": "eq", "value": null}`, "filter.value", "expected a string, a number or a bool"},
+		{`{"and": [{"field": "groups", "op": "eq", "value": "a"}]}`, "filter.and[0]", "unknown field 'groups'"},
+		{`{"field": "song_id", "op": "eq", "value": [1, 2]}`, "filter", "expects a single value"},
+		{`{"field": "song_id", "op": "eq", "value": 1.5}`, "filter", "expected an integer"},
+		{strings.Repeat(`{"not": `, 40) + `{"field": "song_id", "op": "eq", "value": 1}` + strings.Repeat("}", 40), "filter" + strings.Repeat(".not", 32), "nested too deep"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Data, func(t *testing.T) {
+			_, err := filter.ParseJSON([]byte(tc.Data), filter.Songs)
+
+			var filterErr *filter.Error
+			require.True(t, errors.As(err, &filterErr), "expected the filter error, got %v", err)
+			assert.Equal(t, tc.Path, filterErr.Path)
+			assert.Contains(t, filterErr.Message, tc.Message)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package filter_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/filter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	node, err := filter.Parse(`group=="Pink Floyd";(release_date=ge=1970-01-01,!text=null=true)`, filter.Songs)
+	require.NoError(t, err)
+
+	expected := &filter.And{Nodes: []filter.Node{
+		&filter.Comparison{Field: "group", Op: filter.OpEq, Args: []string{"Pink Floyd"}, Values: []any{"Pink Floyd"}, Pos: 1},
+		&filter.Or{Nodes: []filter.Node{
+			&filter.Comparison{
+				Field:  "release_date",
+				Op:     filter.OpGe,
+				Args:   []string{"1970-01-01"},
+				Values: []any{time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+				Pos:    22,
+			},
+			&filter.Not{Node: &filter.Comparison{Field: "text", Op: filter.OpNull, Args: []string{"true"}, Values: []any{true}, Pos: 50}},
+		}},
+	}}
+
+	assert.Equal(t, expected, node)
+}
+
+func TestParseOperators(t *testing.T) {
+	testCases := []struct {
+		Query  string
+		Op     filter.Op
+		Values []any
+	}{
+		{"song_id>12", filter.OpGt, []any{int64(12)}},
+		{"song_id=le=12", filter.OpLe, []any{int64(12)}},
+		{"song_id!=12", filter.OpNe, []any{int64(12)}},
+		{"album=in=(1, 4)", filter.OpIn, []any{int64(1), int64(4)}},
+		{"tags=all=(rock,'hard rock')", filter.OpAll, []any{"rock", "hard rock"}},
+		{"group~=metalica", filter.OpFuzzy, []any{"metalica"}},
+		{"song=like=*love*", filter.OpLike, []any{"*love*"}},
+		{`song=="say \"hi\""`, filter.OpEq, []any{`say "hi"`}},
+		{"release_date==08.02.2024", filter.OpEq, []any{time.Date(2024, 2, 8, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Query, func(t *testing.T) {
+			node, err := filter.Parse(tc.Query, filter.Songs)
+			require.NoError(t, err)
+
+			comparison, ok := node.(*filter.Comparison)
+			require.True(t, ok)
+			assert.Equal(t, tc.Op, comparison.Op)
+			assert.Equal(t, tc.Values, comparison.Values)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		Query   string
+		Pos     int
+		Message string
+	}{
+		{"", 1, "unexpected end of the filter"},
+		{"song_id", 8, "expected an operator"},
+		{"song_id==", 10, "expected a value"},
+		{"song_id==1;", 12, "expected a field"},
+		{"(song_id==1", 12, "')' to close '(' at position 1"},
+		{"song_id==1)", 11, "unexpected ')'"},
+		{"group==Pink Floyd", 13, "quote the values with spaces"},
+		{"group=='Pink Floyd", 8, "unterminated quoted value"},
+		{"group=contains=ping", 6, "unknown operator '=contains='"},
+		{"group=ping", 6, "incomplete operator"},
+		{"groups==ping", 1, "unknown field 'groups'"},
+		{"song_id==1;text==love", 12, "operator 'eq' isn't supported by the field 'text'"},
+		{"song_id=in=1,song_id==(1,2)", 14, "expects a single value"},
+		{"song_id=ge=one", 1, "expected an integer"},
+		{"release_date==2024/02/08", 1, "expected a date"},
+		{"link=null=yes", 1, "expected true or false"},
+		{strings.Repeat("!", 40) + "song_id==1", 33, "nested too deep"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Query, func(t *testing.T) {
+			_, err := filter.Parse(tc.Query, filter.Songs)
+
+			var filterErr *filter.Error
+			require.True(t, errors.As(err, &filterErr), "expected the filter error, got %v", err)
+			assert.Equal(t, tc.Pos, filterErr.Pos)
+			assert.Contains(t, filterErr.Message, tc.Message)
+		})
+	}
+}
+
+func TestHasOp(t *testing.T) {
+	node, err := filter.Parse("song_id>1;!(group~=metalica,song==one)", filter.Songs)
+	require.NoError(t, err)
+
+	assert.True(t, filter.HasOp(node, filter.OpFuzzy))
+	assert.False(t, filter.HasOp(node, filter.OpLike))
+}
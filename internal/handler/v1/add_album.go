@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumAdder interface {
+	Create(ctx context.Context, album *model.Album) error
+}
+
+// @Summary Добавление альбома
+// @Description Метод добавляет в библиотеку альбом без треков, список треков задается методом /albums/{id}/tracks.
+// @Router /albums [post]
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param album body dto.AddAlbumRequest true "Параметры альбома. Дата релиза передается в формате dd.mm.yyyy."
+// @Success 201 {object} dto.GetAlbumResponse "Объект, описывающий добавленный альбом."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func AddAlbum(repo albumAdder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		album, err := parseAddAlbumBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if err := repo.Create(r.Context(), album); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("album has been added", "id", album.ID, "title", album.Title)
+		responseBody := dto.GetAlbumResponse{Album: &dto.Album{ID: album.ID, Title: album.Title, CoverLink: album.CoverLink}}
+		httpkit.Created(w, responseBody)
+	})
+}
+
+func parseAddAlbumBody(r *http.Request) (*model.Album, error) {
+	var data dto.AddAlbumRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse album data", "parseAddAlbumBody", err.Error(), nil)
+	}
+
+	if data.Title == "" {
+		return nil, dto.NewValidationError("incorrect album data", "parseAddAlbumBody", dto.FieldError{Field: "title", Message: "field title is required"})
+	}
+
+	return newAlbumModel(0, data.Title, data.ReleaseDate, data.CoverLink)
+}
+
+// newAlbumModel builds the album model, empty values are left nil
+func newAlbumModel(albumID int64, title string, releaseDate string, coverLink string) (*model.Album, error) {
+	album := &model.Album{ID: albumID, Title: title}
+
+	if releaseDate != "" {
+		date, err := time.Parse("02.01.2006", releaseDate)
+		if err != nil {
+			field := dto.FieldError{Field: "release_date", Message: fmt.Sprintf("release_date=%s, expected format was dd.mm.yyyy", releaseDate)}
+			return nil, dto.NewValidationError("failed to parse release_date field", "newAlbumModel", field)
+		}
+		album.ReleaseDate = &date
+	}
+
+	if coverLink != "" {
+		album.CoverLink = &coverLink
+	}
+
+	return album, nil
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type artistAdder interface {
+	Create(ctx context.Context, artist *model.Artist) error
+}
+
+// @Summary Добавление исполнителя
+// @Description Метод добавляет в библиотеку нового исполнителя. Названия исполнителей уникальны.
+// @Router /artists [post]
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Param artist body dto.AddArtistRequest true "Параметры исполнителя."
+// @Success 201 {object} dto.GetArtistResponse "Объект, описывающий добавленного исполнителя."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 409 {object} dto.Error "Исполнитель уже существует."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func AddArtist(repo artistAdder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artist, err := parseAddArtistBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if err := repo.Create(r.Context(), artist); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("artist has been added", "id", artist.ID, "name", artist.Name)
+		httpkit.Created(w, dto.GetArtistResponse{Artist: &dto.Artist{ID: artist.ID, Name: artist.Name}})
+	})
+}
+
+func parseAddArtistBody(r *http.Request) (*model.Artist, error) {
+	var data dto.AddArtistRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse artist data", "parseAddArtistBody", err.Error(), nil)
+	}
+
+	if data.Name == "" {
+		return nil, dto.NewValidationError("incorrect artist data", "parseAddArtistBody", dto.FieldError{Field: "name", Message: "field name is required"})
+	}
+
+	return &model.Artist{Name: data.Name}, nil
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"reflect"
@@ -10,6 +11,7 @@ import (
 	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/domain/model"
 	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/amicie-monami/music-library/pkg/middleware"
 )
 
 type SongAdder interface {
@@ -17,25 +19,27 @@ type SongAdder interface {
 }
 
 // @Summary Добавление новой песни
-// @Description Метод добавляет в библиотеку основную информацию о песне.
+// @Description Метод добавляет в библиотеку основную информацию о песне. Дополнительная информация (дата релиза, текст, ссылка) запрашивается у внешнего сервиса в фоновом режиме, состояние можно получить методом /songs/{id}/enrichment.
 // @Router /songs [post]
 // @Tags Songs
 // @Accept json
 // @Produce json
 // @Param group body dto.AddSongRequest true "Параметры песни, информацию о которой необходимо добавить в библиотеку."
 // @Success 201 {object} dto.AddSongResponse "Объект, описывающий добавленную песню."
-// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 409 {object} dto.Error "У исполнителя уже есть песня с таким названием (без учёта регистра и лишних пробелов)."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
 func AddSong(repo SongAdder) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		song, err := parseAddSongBody(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
 		if err := repo.Create(r.Context(), song); err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
@@ -51,38 +55,58 @@ func parseAddSongBody(r *http.Request) (*model.Song, error) {
 		return nil, dto.NewError(400, "failed to parse song data", "parseAddSongBody", err.Error(), nil)
 	}
 
+	var fields []dto.FieldError
 	if data.Group == "" {
-		return nil, dto.NewError(400, "incorrect song data", "parseAddSongBody", "field group is required", nil)
+		fields = append(fields, dto.FieldError{Field: "group", Message: "field group is required"})
 	}
 
 	if data.Song == "" {
-		return nil, dto.NewError(400, "incorrect song data", "parseAddSongBody", "field song is required", nil)
+		fields = append(fields, dto.FieldError{Field: "song", Message: "field song is required"})
+	}
+
+	if len(fields) != 0 {
+		return nil, dto.NewValidationError("incorrect song data", "parseAddSongBody", fields...)
 	}
 
 	return &model.Song{Group: data.Group, Name: data.Song}, nil
 }
 
-func sendError(w http.ResponseWriter, err error) {
-	dtoErr, ok := err.(*dto.Error)
-	if !ok {
+// sendError sends the error with the http status of its kind, the unknown errors are sent as internal errors.
+// The error is sent as the problem details of RFC 7807 if the client prefers application/problem+json
+func sendError(w http.ResponseWriter, r *http.Request, err error) {
+	var dtoErr *dto.Error
+	if !errors.As(err, &dtoErr) {
 		// unkonwn error - log level error
-		slog.Error("unkown error", "type", reflect.TypeOf(err), "err", err.Error())
-		httpkit.InternalError(w, &dto.Error{Code: 500, Message: "internal server error"})
-		return
+		slog.Error("unkown error", "type", reflect.TypeOf(err), "err", err.Error(), "request_id", middleware.GetRequestID(r.Context()))
+		dtoErr = dto.NewError(500, "internal server error", "sendError", nil, nil)
+	} else if dto.StatusKind(dtoErr.Code) == "" {
+		// unknown code - log level error
+		slog.Error("unkown error", "code", dtoErr.Code, "err", err.Error(), "request_id", middleware.GetRequestID(r.Context()))
+		dtoErr = dto.NewError(500, "internal server error", "sendError", nil, nil)
+	} else if dtoErr.Code >= 500 {
+		// server error - log level error
+		slog.Error(err.Error(), "request_id", middleware.GetRequestID(r.Context()))
+	} else {
+		// client error - log level info
+		slog.Info(err.Error(), "request_id", middleware.GetRequestID(r.Context()))
 	}
 
-	switch dtoErr.Code {
-	case 400: // bad request - log level info
-		slog.Info(err.Error())
-		httpkit.BadRequest(w, err)
-
-	case 500: // internal server - log level error
-		slog.Error(err.Error())
-		httpkit.InternalError(w, err)
+	if dtoErr.Kind == "" {
+		dtoErr.Kind = dto.StatusKind(dtoErr.Code)
+	}
 
-	default: // unknown code - log level error
-		slog.Error("unkown error", "code", dtoErr, "err", err.Error())
-		httpkit.InternalError(w, &dto.Error{Code: 500, Message: "internal server error"})
+	if httpkit.NegotiateContentType(r, "application/json", httpkit.ProblemContentType) == httpkit.ProblemContentType {
+		httpkit.SendProblem(w, dtoErr.Code, dtoErr.Problem(problemInstance(r)))
 		return
 	}
+
+	httpkit.SendWithCode(w, dtoErr.Code, dtoErr)
+}
+
+// problemInstance identifies the occurrence of the problem by the id of the request
+func problemInstance(r *http.Request) string {
+	if id := middleware.GetRequestID(r.Context()); id != "" {
+		return "urn:request-id:" + id
+	}
+	return ""
 }
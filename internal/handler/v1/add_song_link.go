@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songLinkAdder interface {
+	AddLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error)
+}
+
+// @Summary Добавление ссылки песни
+// @Description Метод добавляет песне ссылку на стриминговую платформу. Платформа (spotify, apple, soundcloud, yandex, youtube или other) определяется по адресу ссылки. Первая ссылка песни становится основной и возвращается в поле link информации о песне.
+// @Router /songs/{id}/links [post]
+// @Tags Links
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param link body dto.AddSongLinkRequest true "Адрес ссылки и признак основной ссылки."
+// @Success 201 {object} dto.GetSongLinksResponse "Все ссылки песни после изменения."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 409 {object} dto.Error "Ссылка уже добавлена."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func AddSongLink(txManager transactor, repo songLinkAdder) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		link, err := parseAddSongLinkBody(songID, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var links []*dto.SongLink
+		tx := func(ctx context.Context) error {
+			links, err = repo.AddLink(ctx, link)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song link has been added", "song_id", songID, "link_id", link.ID, "platform", link.Platform)
+		httpkit.Created(w, dto.GetSongLinksResponse{SongID: songID, Links: links})
+	})
+}
+
+func parseAddSongLinkBody(songID int64, r *http.Request) (*model.SongLink, error) {
+	var data dto.AddSongLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse link data", "parseAddSongLinkBody", err.Error(), nil)
+	}
+
+	if data.URL == "" {
+		return nil, dto.NewValidationError("incorrect link data", "parseAddSongLinkBody", dto.FieldError{Field: "url", Message: "field url is required"})
+	}
+
+	link, fieldErr := normalizeLinkURL("url", data.URL)
+	if fieldErr != nil {
+		return nil, dto.NewValidationError("incorrect link url", "parseAddSongLinkBody", *fieldErr)
+	}
+
+	return &model.SongLink{SongID: songID, URL: link, Platform: model.DetectPlatform(link), Primary: data.Primary}, nil
+}
+
+// normalizeLinkURL validates the link, links without a scheme get the https one.
+// The error of the invalid link is reported for the field of the request
+func normalizeLinkURL(field string, link string) (string, *dto.FieldError) {
+	link = strings.TrimSpace(link)
+	if !strings.Contains(link, "://") {
+		link = "https://" + link
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" || len(link) > 512 {
+		message := fmt.Sprintf("%s=%s, but must be a http(s) url up to 512 characters long", field, link)
+		return "", &dto.FieldError{Field: field, Message: message}
+	}
+
+	return link, nil
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songTagsAttacher interface {
+	AttachTags(ctx context.Context, songID int64, names []string, kind string) ([]*dto.Tag, error)
+}
+
+// @Summary Добавление тегов песне
+// @Description Метод привязывает теги к песне. Неизвестные теги создаются с указанным типом (genre или tag, по умолчанию tag). Названия тегов приводятся к нижнему регистру.
+// @Router /songs/{id}/tags [post]
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param tags body dto.SongTagsRequest true "Названия тегов и тип новых тегов."
+// @Success 200 {object} dto.GetSongTagsResponse "Все теги песни после изменения."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func AttachSongTags(txManager transactor, repo songTagsAttacher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		data, err := parseSongTagsBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var tags []*dto.Tag
+		tx := func(ctx context.Context) error {
+			tags, err = repo.AttachTags(ctx, songID, data.Tags, data.Kind)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("tags have been attached to the song", "song_id", songID, "tags", data.Tags)
+		httpkit.Ok(w, dto.GetSongTagsResponse{SongID: songID, Tags: tags})
+	})
+}
+
+// parseSongTagsBody parses and normalizes the tag names: names are trimmed,
+// lowercased and deduplicated, the kind of new tags defaults to "tag"
+func parseSongTagsBody(r *http.Request) (*dto.SongTagsRequest, error) {
+	var data dto.SongTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse tags", "parseSongTagsBody", err.Error(), nil)
+	}
+
+	if len(data.Tags) == 0 {
+		return nil, dto.NewValidationError("incorrect tags", "parseSongTagsBody", dto.FieldError{Field: "tags", Message: "field tags is required"})
+	}
+
+	if data.Kind == "" {
+		data.Kind = "tag"
+	}
+
+	if err := checkTagKind(data.Kind); err != nil {
+		field := dto.FieldError{Field: "kind", Message: fmt.Sprintf("kind=%s, but must be one of [genre, tag]", data.Kind)}
+		return nil, dto.NewValidationError("incorrect tags", "parseSongTagsBody", field)
+	}
+
+	seen := make(map[string]struct{}, len(data.Tags))
+	names := make([]string, 0, len(data.Tags))
+
+	for _, name := range data.Tags {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || utf8.RuneCountInString(name) > 64 {
+			field := dto.FieldError{Field: "tags", Message: fmt.Sprintf("tag=%q, but must be 1-64 characters long", name)}
+			return nil, dto.NewValidationError("incorrect tags", "parseSongTagsBody", field)
+		}
+
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	data.Tags = names
+	return &data, nil
+}
+
+func checkTagKind(kind string) error {
+	if kind != "genre" && kind != "tag" {
+		details := fmt.Sprintf("kind=%s, but must be one of [genre, tag]", kind)
+		return dto.NewError(400, "invalid tag kind", "checkTagKind", details, nil)
+	}
+	return nil
+}
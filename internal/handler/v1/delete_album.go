@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumDeleter interface {
+	Delete(ctx context.Context, id int64) error
+}
+
+// @Summary Удаление альбома
+// @Description Метод удаляет альбом и его список треков, сами песни остаются в библиотеке.
+// @Router /albums/{id} [delete]
+// @Tags Albums
+// @Produce json
+// @Param id path int true "Идентификатор альбома."
+// @Success 200 {string} string "Альбом удален, нет данных в теле ответа."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Альбом не найден."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func DeleteAlbum(repo albumDeleter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		albumID, err := parsePathVarAlbumID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if err := repo.Delete(r.Context(), albumID); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("album has been deleted", "id", albumID)
+		httpkit.Ok(w, nil)
+	})
+}
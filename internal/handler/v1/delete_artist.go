@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type artistDeleter interface {
+	Delete(ctx context.Context, id int64) error
+}
+
+// @Summary Удаление исполнителя
+// @Description Метод удаляет исполнителя. Исполнителя, у которого есть песни, удалить нельзя.
+// @Router /artists/{id} [delete]
+// @Tags Artists
+// @Produce json
+// @Param id path int true "Идентификатор исполнителя."
+// @Success 200 {string} string "Исполнитель удален, нет данных в теле ответа."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Исполнитель не найден."
+// @Failure 409 {object} dto.Error "У исполнителя есть песни."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func DeleteArtist(repo artistDeleter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artistID, err := parsePathVarArtistID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if err := repo.Delete(r.Context(), artistID); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("artist has been deleted", "id", artistID)
+		httpkit.Ok(w, nil)
+	})
+}
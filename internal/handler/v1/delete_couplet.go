@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// @Summary Удаление куплета песни
+// @Description Метод удаляет один куплет текста песни, следующие куплеты сдвигаются. Если удалён последний куплет, текст песни очищается. Текст читается и изменяется в одной транзакции под блокировкой песни.
+// @Router /songs/{id}/lyrics/couplets/{n} [delete]
+// @Tags Songs
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param n path int true "Номер куплета, начиная с 0 (как offset в /songs/{id}/lyrics)."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412."
+// @Param X-Author header string false "Автор изменения (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения (до 512 символов)."
+// @Success 200 {object} dto.GetSongTextResponse "Все куплеты песни после удаления. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный номер куплета или заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня или куплет не найдены."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func DeleteCouplet(txManager transactor, repo coupletsEditor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		n, err := parsePathVarCoupletIndex(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		couplets, version, err := editCouplets(r, txManager, repo, songID, func(couplets []string) ([]string, error) {
+			if err := checkCoupletIndex(couplets, n); err != nil {
+				return nil, err
+			}
+			return slices.Delete(couplets, n, n+1), nil
+		})
+
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song couplet has been deleted", "song_id", songID, "n", n, "version", version)
+		w.Header().Set("ETag", httpkit.ETag(version))
+		httpkit.Ok(w, dto.GetSongTextResponse{SongID: songID, Couplets: couplets})
+	})
+}
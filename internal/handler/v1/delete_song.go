@@ -13,6 +13,7 @@ import (
 )
 
 type SongDeletter interface {
+	LockSongVersion(ctx context.Context, id int64) (int64, error)
 	Delete(ctx context.Context, id int64) error
 }
 
@@ -23,19 +24,34 @@ type SongDeletter interface {
 // @Accept json
 // @Produce json
 // @Param id path int true "Идентификатор песни, информацию о которой необходимо удалить."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412. Без заголовка песня удаляется безусловно."
 // @Success 200 {string} string "Информация успешно удалена, нет данных в теле ответа."
-// @Failure 404 {object} dto.Error "Неккоректные значения параметров запроса."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
-func DeleteSong(repo SongDeletter) http.Handler {
+func DeleteSong(txManager transactor, repo SongDeletter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		songID, err := parsePathVarSongID(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
-		if err := repo.Delete(r.Context(), songID); err != nil {
-			sendError(w, err)
+		tx := func(ctx context.Context) error {
+			version, err := repo.LockSongVersion(ctx, songID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkIfMatch(r, version); err != nil {
+				return err
+			}
+			return repo.Delete(ctx, songID)
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
 			return
 		}
 
@@ -45,17 +61,22 @@ func DeleteSong(repo SongDeletter) http.Handler {
 }
 
 func parsePathVarSongID(r *http.Request) (int64, error) {
-	songID, err := strconv.ParseInt(mux.Vars(r)["id"], 0, 10)
+	return parsePathVarID(r, "id", "song")
+}
+
+// parsePathVarID parses the positive identifier of the entity from the url path variable
+func parsePathVarID(r *http.Request, key string, entity string) (int64, error) {
+	id, err := strconv.ParseInt(mux.Vars(r)[key], 0, 64)
 
 	if err != nil {
-		details := fmt.Sprintf("id=%s", mux.Vars(r)["id"])
-		return 0, dto.NewError(400, "invalid song id in url", "parsePathVarSongID", details, nil)
+		details := fmt.Sprintf("%s=%s", key, mux.Vars(r)[key])
+		return 0, dto.NewError(400, fmt.Sprintf("invalid %s id in url", entity), "parsePathVarID", details, nil)
 	}
 
-	if songID <= 0 {
-		details := fmt.Sprintf("id=%s but must me > 0", mux.Vars(r)["id"])
-		return 0, dto.NewError(400, "invalid song id in url", "parsePathVarSongID", details, nil)
+	if id <= 0 {
+		details := fmt.Sprintf("%s=%s but must me > 0", key, mux.Vars(r)[key])
+		return 0, dto.NewError(400, fmt.Sprintf("invalid %s id in url", entity), "parsePathVarID", details, nil)
 	}
 
-	return songID, nil
+	return id, nil
 }
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songLinkDeleter interface {
+	DeleteLink(ctx context.Context, songID int64, linkID int64) ([]*dto.SongLink, error)
+}
+
+// @Summary Удаление ссылки песни
+// @Description Метод удаляет ссылку песни. Если удалена основная ссылка, основной становится самая старая из оставшихся.
+// @Router /songs/{id}/links/{link_id} [delete]
+// @Tags Links
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param link_id path int true "Идентификатор ссылки."
+// @Success 200 {object} dto.GetSongLinksResponse "Оставшиеся ссылки песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Ссылка не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func DeleteSongLink(txManager transactor, repo songLinkDeleter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		linkID, err := parsePathVarLinkID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var links []*dto.SongLink
+		tx := func(ctx context.Context) error {
+			links, err = repo.DeleteLink(ctx, songID, linkID)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song link has been deleted", "song_id", songID, "link_id", linkID)
+		httpkit.Ok(w, dto.GetSongLinksResponse{SongID: songID, Links: links})
+	})
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songTagsDetacher interface {
+	DetachTags(ctx context.Context, songID int64, names []string) ([]*dto.Tag, error)
+}
+
+// @Summary Удаление тегов песни
+// @Description Метод отвязывает теги от песни, сами теги остаются в библиотеке.
+// @Router /songs/{id}/tags [delete]
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param tags body dto.SongTagsRequest true "Названия тегов, которые необходимо отвязать."
+// @Success 200 {object} dto.GetSongTagsResponse "Оставшиеся теги песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func DetachSongTags(txManager transactor, repo songTagsDetacher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		data, err := parseSongTagsBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var tags []*dto.Tag
+		tx := func(ctx context.Context) error {
+			tags, err = repo.DetachTags(ctx, songID, data.Tags)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("tags have been detached from the song", "song_id", songID, "tags", data.Tags)
+		httpkit.Ok(w, dto.GetSongTagsResponse{SongID: songID, Tags: tags})
+	})
+}
@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// exportFlushRows is the number of the rows after which the response is flushed to the client
+const exportFlushRows = 1000
+
+// exportDefaultFields are the fields of the export if the fields param isn't set
+var exportDefaultFields = []string{"song_id", "group", "song", "release_date", "link", "text"}
+
+type songExporter interface {
+	ExportSongs(ctx context.Context, aggregation map[string]any, fn func(song *dto.SongWithDetails) error) error
+}
+
+// @Summary Экспорт библиотеки
+// @Description Метод выгружает все песни библиотеки или песни, прошедшие фильтр, в виде файла. Песни передаются потоком по мере чтения из базы данных и упорядочены по идентификатору, ограничение в 1000 песен не применяется.
+// @Router /songs/export [get]
+// @Tags Songs
+// @Produce plain
+// @Produce json
+// @Param format query string false "Формат файла: csv (по умолчанию, первая строка - названия полей, теги разделяются знаком ”|”), ndjson (объект песни в каждой строке) или json (массив песен)."
+// @Param fields query string false "Список полей, которые необходимо выгрузить (см. /songs). По умолчанию: song_id, group, song, release_date, link, text."
+// @Param filter query string false "Фильтр песен (см. /songs)."
+// @Success 200 {file} file "Файл с песнями."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func ExportSongs(repo songExporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, fields, params, err := parseExportSongsQueryParams(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		export := &songsExport{w: w, format: format, fields: fields}
+		err = repo.ExportSongs(r.Context(), params, export.write)
+		if err == nil {
+			err = export.end()
+		}
+
+		if err != nil {
+			//the status is already sent with the first rows, the client gets the cut file
+			if export.started {
+				slog.Error("failed to export songs", "rows", export.rows, "err", err)
+				return
+			}
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("songs have been exported", "format", format, "rows", export.rows)
+	})
+}
+
+func parseExportSongsQueryParams(r *http.Request) (string, []string, map[string]any, error) {
+	format := httpkit.GetStrParam("format", r)
+	switch format {
+	case "":
+		format = "csv"
+	case "csv", "ndjson", "json":
+	default:
+		details := fmt.Sprintf("format=%s, but must be one of [csv, ndjson, json]", format)
+		return "", nil, nil, dto.NewError(400, "invalid format param", "parseExportSongsQueryParams", details, nil)
+	}
+
+	fields := strings.Fields(httpkit.GetStrParam("fields", r))
+	if len(fields) == 0 {
+		fields = exportDefaultFields
+	}
+
+	columns, err := checkGetSongsFields(fields)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	filterNode, err := parseGetSongsDataFilterParams(r)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	return format, fields, map[string]any{"filter": filterNode, "fields": columns}, nil
+}
+
+// songsExport writes the songs to the response in the format. The response starts with the first song,
+// so the errors which happen before it are sent as usual
+type songsExport struct {
+	w       http.ResponseWriter
+	format  string
+	fields  []string
+	started bool
+	rows    int
+
+	csv     *csv.Writer
+	encoder *json.Encoder
+}
+
+var exportContentTypes = map[string]string{
+	"csv":    "text/csv; charset=utf-8",
+	"ndjson": "application/x-ndjson",
+	"json":   "application/json",
+}
+
+func (e *songsExport) start() error {
+	e.started = true
+
+	//the export can take longer than the write timeout of the server
+	controller := http.NewResponseController(e.w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && err != http.ErrNotSupported {
+		slog.Warn("failed to reset the write deadline of the export", "err", err)
+	}
+
+	e.w.Header().Set("Content-Type", exportContentTypes[e.format])
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="songs.%s"`, e.format))
+	e.w.WriteHeader(http.StatusOK)
+
+	switch e.format {
+	case "csv":
+		e.csv = csv.NewWriter(e.w)
+		return e.csv.Write(e.fields)
+	case "json":
+		_, err := io.WriteString(e.w, "[")
+		e.encoder = json.NewEncoder(e.w)
+		return err
+	default:
+		e.encoder = json.NewEncoder(e.w)
+		return nil
+	}
+}
+
+func (e *songsExport) write(song *dto.SongWithDetails) error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeSong(song); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%exportFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+func (e *songsExport) writeSong(song *dto.SongWithDetails) error {
+	switch e.format {
+	case "csv":
+		record := make([]string, 0, len(e.fields))
+		for _, field := range e.fields {
+			record = append(record, exportCSVValue(song, field))
+		}
+		return e.csv.Write(record)
+
+	case "json":
+		if e.rows > 0 {
+			if _, err := io.WriteString(e.w, ","); err != nil {
+				return err
+			}
+		}
+		//the encoder ends each song with the new line
+		return e.encoder.Encode(song)
+
+	default:
+		return e.encoder.Encode(song)
+	}
+}
+
+// end finishes the export, the empty export is a valid file as well
+func (e *songsExport) end() error {
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	if e.format == "json" {
+		if _, err := io.WriteString(e.w, "]\n"); err != nil {
+			return err
+		}
+	}
+
+	return e.flush()
+}
+
+func (e *songsExport) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := http.NewResponseController(e.w).Flush(); err != nil && err != http.ErrNotSupported {
+		return err
+	}
+	return nil
+}
+
+// exportCSVValue returns the value of the song field, the empty string for the missing values
+func exportCSVValue(song *dto.SongWithDetails, field string) string {
+	value := func(ptr *string) string {
+		if ptr == nil {
+			return ""
+		}
+		return *ptr
+	}
+
+	switch field {
+	case "song_id":
+		return strconv.FormatInt(song.ID, 10)
+	case "group":
+		return song.Group
+	case "song":
+		return song.Title
+	case "release_date":
+		return value(song.ReleaseDate)
+	case "link":
+		return value(song.Link)
+	case "text":
+		return value(song.Text)
+	case "tags":
+		return strings.Join(song.Tags, "|")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumGetter interface {
+	GetAlbum(ctx context.Context, id int64) (*dto.Album, error)
+}
+
+// @Summary Информация об альбоме
+// @Description Метод возвращает альбом и его треки в порядке следования.
+// @Router /albums/{id} [get]
+// @Tags Albums
+// @Produce json
+// @Param id path int true "Идентификатор альбома."
+// @Success 200 {object} dto.GetAlbumResponse "Объект, описывающий альбом."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Альбом не найден."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetAlbum(repo albumGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		albumID, err := parsePathVarAlbumID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		album, err := repo.GetAlbum(r.Context(), albumID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("album has been found", "id", album.ID)
+		httpkit.Ok(w, dto.GetAlbumResponse{Album: album})
+	})
+}
+
+func parsePathVarAlbumID(r *http.Request) (int64, error) {
+	return parsePathVarID(r, "id", "album")
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumsGetter interface {
+	GetAlbums(ctx context.Context, title string, limit int64, offset int64) ([]*dto.Album, error)
+}
+
+// @Summary Получение списка альбомов
+// @Description Метод возвращает альбомы библиотеки с количеством треков, поддерживает пагинацию и фильтрацию по названию.
+// @Router /albums [get]
+// @Tags Albums
+// @Produce json
+// @Param title query string false "Фильтр по названию альбома. Поддерживает оператор * регулярных выражений, нечувствителен к регистру. Пример: title=\*moon\*."
+// @Param limit query string false "Количество альбомов, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества альбомов. Стандартное значение 0."
+// @Success 200 {object} dto.GetAlbumsResponse "Список альбомов."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetAlbums(repo albumsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		albums, err := repo.GetAlbums(r.Context(), httpkit.GetStrParam("title", r), limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("albums have been found", "count", len(albums))
+		httpkit.Ok(w, dto.GetAlbumsResponse{Albums: albums})
+	})
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type artistGetter interface {
+	GetArtist(ctx context.Context, id int64) (*dto.Artist, error)
+}
+
+// @Summary Информация об исполнителе
+// @Description Метод возвращает исполнителя и количество его песен.
+// @Router /artists/{id} [get]
+// @Tags Artists
+// @Produce json
+// @Param id path int true "Идентификатор исполнителя."
+// @Success 200 {object} dto.GetArtistResponse "Объект, описывающий исполнителя."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Исполнитель не найден."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetArtist(repo artistGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artistID, err := parsePathVarArtistID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		artist, err := repo.GetArtist(r.Context(), artistID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("artist has been found", "id", artist.ID)
+		httpkit.Ok(w, dto.GetArtistResponse{Artist: artist})
+	})
+}
+
+func parsePathVarArtistID(r *http.Request) (int64, error) {
+	return parsePathVarID(r, "id", "artist")
+}
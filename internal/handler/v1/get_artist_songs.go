@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/filter"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// @Summary Песни исполнителя
+// @Description Метод возвращает песни исполнителя, поддерживает пагинацию (в том числе курсорную), сортировку и выбор полей аналогично методу /songs.
+// @Router /artists/{id}/songs [get]
+// @Tags Artists
+// @Produce json
+// @Param id path int true "Идентификатор исполнителя."
+// @Param limit query string false "Количество песен, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества песен. Стандартное значение 0."
+// @Param fields query string false "Список полей, которые необходимо вернуть (см. /songs)."
+// @Param sort query string false "Порядок песен (см. /songs)."
+// @Param cursor query string false "Курсор страницы (см. /songs)."
+// @Param total query string false "Режим подсчета общего количества песен (см. /songs)."
+// @Success 200 {object} dto.GetSongsResponse "Список песен исполнителя."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 404 {object} dto.Error "Исполнитель не найден."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetArtistSongs(artists artistGetter, songs songDataGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artistID, err := parsePathVarArtistID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		params, err := parseGetArtistSongsQueryParams(artistID, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		//an unknown artist isn't the same as an artist without songs
+		if _, err := artists.GetArtist(r.Context(), artistID); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		artistSongs, err := songs.GetSongs(r.Context(), params)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		setSongsETags(artistSongs.Songs)
+
+		slog.Info("artist songs have been found", "artist_id", artistID, "count", len(artistSongs.Songs))
+		httpkit.Ok(w, artistSongs)
+	})
+}
+
+func parseGetArtistSongsQueryParams(artistID int64, r *http.Request) (map[string]any, error) {
+	limit, err := parseLimitParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := parseOffsetParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := parseGetSongsFieldsParam(r)
+	if err != nil {
+		return nil, err
+	}
+
+	artistFilter := &filter.Comparison{Field: "artist_id", Op: filter.OpEq, Values: []any{artistID}}
+
+	sort, err := parseGetSongsSortParam(r, artistFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, total, err := parseGetSongsPageParams(r, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"filter": artistFilter,
+		"limit":  limit,
+		"offset": offset,
+		"fields": fields,
+		"sort":   sort,
+		"cursor": cursor,
+		"total":  total,
+	}, nil
+}
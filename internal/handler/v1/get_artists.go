@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type artistsGetter interface {
+	GetArtists(ctx context.Context, name string, limit int64, offset int64) ([]*dto.Artist, error)
+}
+
+// @Summary Получение списка исполнителей
+// @Description Метод возвращает исполнителей библиотеки с количеством их песен, поддерживает пагинацию и фильтрацию по названию.
+// @Router /artists [get]
+// @Tags Artists
+// @Produce json
+// @Param name query string false "Фильтр по названию исполнителя. Поддерживает оператор * регулярных выражений, нечувствителен к регистру. Пример: name=\*floyd\*."
+// @Param limit query string false "Количество исполнителей, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества исполнителей. Стандартное значение 0."
+// @Success 200 {object} dto.GetArtistsResponse "Список исполнителей."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetArtists(repo artistsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		artists, err := repo.GetArtists(r.Context(), httpkit.GetStrParam("name", r), limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("artists have been found", "count", len(artists))
+		httpkit.Ok(w, dto.GetArtistsResponse{Artists: artists})
+	})
+}
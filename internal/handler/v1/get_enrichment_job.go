@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type enrichmentJobGetter interface {
+	GetJob(ctx context.Context, songID int64) (*dto.EnrichmentJob, error)
+}
+
+// @Summary Состояние обогащения данных песни
+// @Description Метод возвращает состояние фоновой задачи, запрашивающей дополнительную информацию о песне у внешнего сервиса. Возможные статусы: [pending, running, done, failed].
+// @Router /songs/{id}/enrichment [get]
+// @Tags Enrichment
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Success 200 {object} dto.GetEnrichmentJobResponse "Состояние задачи."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Задача не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetEnrichmentJob(repo enrichmentJobGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		job, err := repo.GetJob(r.Context(), songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("enrichment job has been found", "song_id", songID, "status", job.Status)
+		httpkit.Ok(w, dto.GetEnrichmentJobResponse{Job: job})
+	})
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/amicie-monami/music-library/pkg/textdiff"
+)
+
+// lyricsDiffContext is the number of the unchanged lines around the changes of the diff
+const lyricsDiffContext = 3
+
+// lyricsDiffContentType is the content type of the diff sent as the plain text
+const lyricsDiffContentType = "text/x-diff"
+
+type lyricsRevisionGetter interface {
+	GetLyricsRevision(ctx context.Context, songID int64, revision int64) (*dto.LyricsRevision, error)
+}
+
+// @Summary Сравнение ревизий текста песни
+// @Description Метод возвращает построчное сравнение двух ревизий текста песни в формате unified diff. Заголовок каждого блока изменений содержит номер куплета, в котором начинаются изменения, например "@@ -3,3 +3,3 @@ couplet 2".
+// @Description Если заголовок Accept запрашивает text/x-diff, сравнение возвращается обычным текстом.
+// @Router /songs/{id}/lyrics/diff [get]
+// @Tags Songs
+// @Produce json
+// @Produce text/x-diff
+// @Param id path int true "Идентификатор песни."
+// @Param from query int true "Номер исходной ревизии."
+// @Param to query int true "Номер ревизии, с которой сравнивается исходная."
+// @Success 200 {object} dto.GetLyricsDiffResponse "Сравнение ревизий, пустое поле unified означает одинаковые тексты."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 404 {object} dto.Error "Песня или ревизия не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetLyricsDiff(repo lyricsRevisionGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		fromRevision, toRevision, err := parseGetLyricsDiffQueryParams(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		from, err := repo.GetLyricsRevision(r.Context(), songID, fromRevision)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		to, err := repo.GetLyricsRevision(r.Context(), songID, toRevision)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		diff := diffLyrics(songID, from, to)
+		slog.Info("lyrics revisions have been compared", "song_id", songID, "from", fromRevision, "to", toRevision, "hunks", len(diff.Hunks))
+
+		if httpkit.NegotiateContentType(r, "application/json", lyricsDiffContentType) == lyricsDiffContentType {
+			w.Header().Set("Content-Type", lyricsDiffContentType+"; charset=utf-8")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, diff.Unified)
+			return
+		}
+
+		httpkit.Ok(w, diff)
+	})
+}
+
+func parseGetLyricsDiffQueryParams(r *http.Request) (int64, int64, error) {
+	from, err := parseRevisionParam(r, "from")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	to, err := parseRevisionParam(r, "to")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return from, to, nil
+}
+
+// parseRevisionParam parses the required number of the revision from the query param
+func parseRevisionParam(r *http.Request, key string) (int64, error) {
+	param := r.URL.Query().Get(key)
+	if param == "" {
+		details := fmt.Sprintf("%s param is required", key)
+		return 0, dto.NewError(400, fmt.Sprintf("missing %s param", key), "parseRevisionParam", details, nil)
+	}
+
+	revision, err := strconv.ParseInt(param, 10, 64)
+	if err != nil || revision <= 0 {
+		details := fmt.Sprintf("%s=%s, but must be a revision number > 0", key, param)
+		return 0, dto.NewError(400, fmt.Sprintf("invalid %s param", key), "parseRevisionParam", details, nil)
+	}
+
+	return revision, nil
+}
+
+// diffLyrics compares the texts of the revisions line by line, each hunk is headed by the couplet
+// where its changes start
+func diffLyrics(songID int64, from *dto.LyricsRevision, to *dto.LyricsRevision) *dto.GetLyricsDiffResponse {
+	fromLines, toLines := lyricsLines(from.Text), lyricsLines(to.Text)
+	hunks := textdiff.Hunks(textdiff.Lines(fromLines, toLines), lyricsDiffContext)
+
+	response := &dto.GetLyricsDiffResponse{
+		SongID: songID,
+		From:   from.Revision,
+		To:     to.Revision,
+		Hunks:  make([]*dto.LyricsDiffHunk, 0, len(hunks)),
+	}
+
+	for _, hunk := range hunks {
+		couplet := hunkCouplet(hunk, fromLines, toLines)
+		hunk.Section = fmt.Sprintf("couplet %d", couplet)
+
+		lines := make([]string, 0, len(hunk.Lines))
+		for _, line := range hunk.Lines {
+			lines = append(lines, line.String())
+		}
+
+		response.Hunks = append(response.Hunks, &dto.LyricsDiffHunk{Header: hunk.Header(), Couplet: couplet, Lines: lines})
+	}
+
+	fromName, toName := fmt.Sprintf("revision %d", from.Revision), fmt.Sprintf("revision %d", to.Revision)
+	response.Unified = textdiff.Unified(fromName, toName, hunks)
+	return response
+}
+
+// lyricsLines splits the lyrics into the lines, the missing lyrics have no lines
+func lyricsLines(text *string) []string {
+	if text == nil || *text == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(*text, `\n`, "\n"), "\n")
+}
+
+// hunkCouplet returns the couplet of the first changed line of the hunk, the couplet of the deleted line
+// is counted in the old text
+func hunkCouplet(hunk *textdiff.Hunk, fromLines []string, toLines []string) int {
+	for _, line := range hunk.Lines {
+		switch line.Kind {
+		case textdiff.Insert:
+			return lineCouplet(toLines, line.ToLine-1)
+		case textdiff.Delete:
+			return lineCouplet(fromLines, line.FromLine-1)
+		}
+	}
+	return 1
+}
+
+// lineCouplet returns the number of the couplet of the line starting from 1, the couplets are separated
+// by the empty lines and the empty lines belong to the couplet before them
+func lineCouplet(lines []string, idx int) int {
+	couplet, hasText := 1, false
+	for i, line := range lines[:idx+1] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if hasText && strings.TrimSpace(lines[i-1]) == "" {
+			couplet++
+		}
+		hasText = true
+	}
+	return couplet
+}
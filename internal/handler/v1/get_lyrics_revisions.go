@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type lyricsRevisionsGetter interface {
+	GetLyricsRevisions(ctx context.Context, songID int64, limit int64, offset int64) ([]*dto.LyricsRevision, error)
+}
+
+// @Summary Получение истории изменений текста песни
+// @Description Метод возвращает ревизии текста песни от новых к старым без самих текстов. Ревизия создаётся при каждом изменении текста, автор и причина изменения передаются заголовками X-Author и X-Change-Reason.
+// @Router /songs/{id}/lyrics/revisions [get]
+// @Tags Songs
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param limit query int false "Количество ревизий, которое необходимо вернуть. По умолчанию 10, не больше 1000."
+// @Param offset query int false "Смещение, необходимое для выборки определенного подмножества ревизий."
+// @Success 200 {object} dto.GetLyricsRevisionsResponse "Ревизии текста песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetLyricsRevisions(repo lyricsRevisionsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		revisions, err := repo.GetLyricsRevisions(r.Context(), songID, limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("lyrics revisions have been found", "song_id", songID, "count", len(revisions))
+		httpkit.Ok(w, dto.GetLyricsRevisionsResponse{SongID: songID, Revisions: revisions})
+	})
+}
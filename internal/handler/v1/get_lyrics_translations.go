@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"golang.org/x/text/language"
+)
+
+type lyricsTranslationsGetter interface {
+	GetTranslations(ctx context.Context, songID int64) ([]*dto.LyricsTranslation, error)
+}
+
+// @Summary Получение переводов текста песни
+// @Description Метод возвращает все переводы текста песни, упорядоченные по языку.
+// @Router /songs/{id}/translations [get]
+// @Tags Songs
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Success 200 {object} dto.GetLyricsTranslationsResponse "Переводы текста песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор песни."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetLyricsTranslations(repo lyricsTranslationsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		translations, err := repo.GetTranslations(r.Context(), songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("lyrics translations have been found", "song_id", songID, "count", len(translations))
+		httpkit.Ok(w, dto.GetLyricsTranslationsResponse{SongID: songID, Translations: translations})
+	})
+}
+
+func parseTranslationLangParam(r *http.Request) (string, error) {
+	lang := httpkit.GetStrParam("lang", r)
+	if lang == "" {
+		return "", nil
+	}
+	return parseLanguageTag("lang", lang)
+}
+
+// maxLanguageTagLength is the maximum length of the language tag kept by the database
+const maxLanguageTagLength = 35
+
+// parseLanguageTag parses the BCP 47 language tag and returns its canonical form, en-us and EN-US are en-US
+func parseLanguageTag(key string, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > maxLanguageTagLength {
+		details := fmt.Sprintf("%s has %d characters, but must be <= %d", key, len(value), maxLanguageTagLength)
+		return "", dto.NewError(400, fmt.Sprintf("invalid %s", key), "parseLanguageTag", details, nil)
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil || tag == language.Und {
+		details := fmt.Sprintf("%s=%s, but must be a BCP 47 language tag, for example en or pt-BR", key, value)
+		return "", dto.NewError(400, fmt.Sprintf("invalid %s", key), "parseLanguageTag", details, nil)
+	}
+	return tag.String(), nil
+}
@@ -20,20 +20,32 @@ type songDetailsGetter interface {
 // @Produce json
 // @Param group query string true "Название группы"
 // @Param song query string  true "Название песни"
-// @Success 201 {object} dto.GetSongDetailsResponse "Объект, описывающий основную и дополнительную информацию о песне."
+// @Param If-None-Match header string false "ETag песни, полученный ранее. Если песня не изменилась, возвращается 304 без тела."
+// @Success 201 {object} dto.GetSongDetailsResponse "Объект, описывающий основную и дополнительную информацию о песне. Заголовок ETag содержит версию песни."
+// @Success 304 {string} string "Песня не изменилась."
 // @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 404 {object} dto.Error "Песня не найдена."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
 func GetSongDetails(repo songDetailsGetter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		params, err := parseGetSongDetailsQueryParams(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
 		songWithDetails, err := repo.GetSongWithDetails(r.Context(), params["group"], params["song"])
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
+			return
+		}
+
+		songWithDetails.ETag = httpkit.ETag(songWithDetails.Version)
+		w.Header().Set("ETag", songWithDetails.ETag)
+
+		if header := r.Header.Get("If-None-Match"); header != "" && httpkit.MatchETag(header, songWithDetails.ETag, true) {
+			slog.Info("song hasn't been modified", "id", songWithDetails.ID)
+			w.WriteHeader(http.StatusNotModified)
 			return
 		}
 
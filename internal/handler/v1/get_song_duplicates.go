@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songDuplicatesGetter interface {
+	GetDuplicates(ctx context.Context, match string, limit, offset int64) ([]*dto.SongDuplicates, error)
+}
+
+// @Summary Отчёт о дубликатах песен
+// @Description Метод возвращает группы песен с одинаковыми названиями исполнителя и песни. Группа совпадает точно (exact), если названия песен группы полностью одинаковы, и после нормализации (normalized), если они отличаются только регистром и пробелами. Песни группы упорядочены по идентификатору, дубликаты можно объединить методом /songs/{id}/merge.
+// @Router /songs/duplicates [get]
+// @Tags Songs
+// @Produce json
+// @Param match query string false "Вид совпадения: exact, normalized или all (по умолчанию)."
+// @Param limit query string false "Количество групп, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества групп. Стандартное значение 0."
+// @Success 200 {object} dto.GetSongDuplicatesResponse "Список групп дубликатов."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetSongDuplicates(repo songDuplicatesGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match, err := parseDuplicatesMatchParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		duplicates, err := repo.GetDuplicates(r.Context(), match, limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song duplicates have been found", "match", match, "count", len(duplicates))
+		httpkit.Ok(w, dto.GetSongDuplicatesResponse{Duplicates: duplicates})
+	})
+}
+
+// parseDuplicatesMatchParam returns the match of the duplicates, the empty match is any match
+func parseDuplicatesMatchParam(r *http.Request) (string, error) {
+	match := httpkit.GetStrParam("match", r)
+	switch match {
+	case "", "all":
+		return "", nil
+	case model.DuplicateExact, model.DuplicateNormalized:
+		return match, nil
+	default:
+		details := fmt.Sprintf("match=%s, but must be one of [exact, normalized, all]", match)
+		return "", dto.NewError(400, "invalid match param", "parseDuplicatesMatchParam", details, nil)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songLinksGetter interface {
+	GetLinks(ctx context.Context, songID int64) ([]*dto.SongLink, error)
+}
+
+// @Summary Получение ссылок песни
+// @Description Метод возвращает ссылки песни на стриминговых платформах, основная ссылка идет первой.
+// @Router /songs/{id}/links [get]
+// @Tags Links
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Success 200 {object} dto.GetSongLinksResponse "Список ссылок песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetSongLinks(repo songLinksGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		links, err := repo.GetLinks(r.Context(), songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song links have been found", "song_id", songID, "count", len(links))
+		httpkit.Ok(w, dto.GetSongLinksResponse{SongID: songID, Links: links})
+	})
+}
+
+func parsePathVarLinkID(r *http.Request) (int64, error) {
+	return parsePathVarID(r, "link_id", "link")
+}
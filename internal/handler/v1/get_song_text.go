@@ -2,62 +2,172 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/amicie-monami/music-library/pkg/lrc"
 )
 
+// lrcContentType is the content type of the lyrics in the LRC format
+const lrcContentType = "text/x-lrc"
+
 type songTextGetter interface {
 	GetSongText(ctx context.Context, id int64) (*string, error)
+	GetTranslation(ctx context.Context, songID int64, lang string) (*dto.LyricsTranslation, error)
+	syncedLyricsGetter
+}
+
+type syncedLyricsGetter interface {
+	GetSyncedLyrics(ctx context.Context, songID int64) ([]*dto.SyncedLyricsLine, error)
 }
 
 // @Summary Получение текста песни с пагинацией по куплетам
 // @Router /songs/{id}/lyrics [get]
 // @Description Метод возвращает текст песни в куплетах. Если не заданы параметры пагинации, возвращаются все куплеты.
+// @Description С параметром format возвращается синхронизированный текст: json - строки со временем начала и окончания в миллисекундах (dto.GetSyncedLyricsResponse), lrc - файл в формате LRC (enhanced LRC для строк с разметкой слов).
 // @Tags Songs
 // @Accept json
 // @Produce json
+// @Produce text/x-lrc
 // @Param id path string true "Идентификатор песни, текст которой необходимо получить."
+// @Param format query string false "Формат синхронизированного текста: json или lrc. Без параметра возвращаются куплеты."
+// @Param lang query string false "Язык перевода (BCP 47, например en или pt-BR). Перевод возвращается в поле translation, его куплеты выровнены с куплетами оригинала и разбиваются на страницы вместе с ними. Не совместим с параметром format."
 // @Param limit query string false "Количество куплетов, которое необходимо верунть."
 // @Param offset query string false "Смещение, необходимое для выборки определенного подмножества куплетов."
 // @Success 200 {object} dto.GetSongTextResponse "Текст песни"
 // @Failure 400 {object} dto.Error "Неверный запрос, некорректые значения параметров."
+// @Failure 404 {object} dto.Error "Песня, перевод или синхронизированный текст не найдены."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
 func GetSongText(repo songTextGetter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		songID, err := parsePathVarSongID(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
+			return
+		}
+
+		format, err := parseLyricsFormatParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		lang, err := parseTranslationLangParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if format != "" && lang != "" {
+			details := "the translation is returned only with the couplets"
+			sendError(w, r, dto.NewError(400, "lang and format params can't be combined", "GetSongText", details, nil))
+			return
+		}
+
+		if format != "" {
+			sendSyncedLyrics(w, r, repo, songID, format)
 			return
 		}
 
 		limit, offset, err := parseGetSongTextQueryParams(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
 		songText, err := repo.GetSongText(r.Context(), songID)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
-		couplets, err := coupletsPagination(songText, limit, offset)
+		var translationText *string
+		if lang != "" {
+			translation, err := repo.GetTranslation(r.Context(), songID, lang)
+			if err != nil {
+				sendError(w, r, err)
+				return
+			}
+			translationText = &translation.Text
+		}
+
+		couplets, translation, err := coupletsPagination(songText, translationText, limit, offset)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
-		slog.Info("song lyrics have been found", "song_id", songID)
-		responseBody := dto.GetSongTextResponse{Couplets: couplets, SongID: songID}
+		slog.Info("song lyrics have been found", "song_id", songID, "lang", lang)
+		responseBody := dto.GetSongTextResponse{Couplets: couplets, SongID: songID, Lang: lang, Translation: translation}
 		httpkit.Ok(w, responseBody)
 	})
 }
 
+// sendSyncedLyrics sends the timed lines of the lyrics as the json or the LRC file
+func sendSyncedLyrics(w http.ResponseWriter, r *http.Request, repo syncedLyricsGetter, songID int64, format string) {
+	lines, err := getSyncedLyrics(r.Context(), repo, songID)
+	if err != nil {
+		sendError(w, r, err)
+		return
+	}
+
+	slog.Info("synced song lyrics have been found", "song_id", songID, "format", format, "lines", len(lines))
+
+	if format == "lrc" {
+		w.Header().Set("Content-Type", lrcContentType+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, lrc.Format(lrcLines(lines)))
+		return
+	}
+
+	httpkit.Ok(w, dto.GetSyncedLyricsResponse{SongID: songID, Lines: lines})
+}
+
+func parseLyricsFormatParam(r *http.Request) (string, error) {
+	format := httpkit.GetStrParam("format", r)
+	switch format {
+	case "", "json", "lrc":
+		return format, nil
+	default:
+		details := fmt.Sprintf("format=%s, but must be one of [json, lrc]", format)
+		return "", dto.NewError(400, "invalid format param", "parseLyricsFormatParam", details, nil)
+	}
+}
+
+// getSyncedLyrics returns the timed lines of the lyrics, the song without the timing isn't found
+func getSyncedLyrics(ctx context.Context, repo syncedLyricsGetter, songID int64) ([]*dto.SyncedLyricsLine, error) {
+	lines, err := repo.GetSyncedLyrics(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		details := fmt.Sprintf("id=%d", songID)
+		return nil, dto.NewNotFoundError("song has no synced lyrics", "getSyncedLyrics", details)
+	}
+
+	return lines, nil
+}
+
+// lrcLines converts the timed lines of the lyrics to the lines of the LRC file
+func lrcLines(lines []*dto.SyncedLyricsLine) []lrc.Line {
+	result := make([]lrc.Line, 0, len(lines))
+	for _, line := range lines {
+		lrcLine := lrc.Line{Start: time.Duration(line.StartMs) * time.Millisecond, Text: line.Text}
+		for _, word := range line.Words {
+			lrcLine.Words = append(lrcLine.Words, lrc.Word{Start: time.Duration(word.StartMs) * time.Millisecond, Text: word.Text})
+		}
+		result = append(result, lrcLine)
+	}
+	return result
+}
+
 func parseGetSongTextQueryParams(r *http.Request) (int64, int64, error) {
 	limit, err := parseLimitParam(r)
 	if err != nil {
@@ -72,14 +182,22 @@ func parseGetSongTextQueryParams(r *http.Request) (int64, int64, error) {
 	return limit, offset, nil
 }
 
-func coupletsPagination(text *string, limit int64, offset int64) ([]string, error) {
+// coupletsPagination returns the page of the couplets of the text and the same page of the couplets
+// of the translation aligned with them, the translation is nil if it isn't requested
+func coupletsPagination(text *string, translation *string, limit int64, offset int64) ([]string, []*string, error) {
 	if text == nil {
-		return nil, nil
+		return nil, nil, nil
+	}
+
+	couplets := splitCouplets(*text)
+
+	var translated []*string
+	if translation != nil {
+		translated = alignCouplets(len(couplets), splitCouplets(*translation))
 	}
 
-	couplets := strings.Split(strings.ReplaceAll(*text, `\n`, "\n"), "\n\n")
 	if limit == 0 && offset == 0 {
-		return couplets, nil
+		return couplets, translated, nil
 	}
 
 	if limit == 0 {
@@ -87,12 +205,34 @@ func coupletsPagination(text *string, limit int64, offset int64) ([]string, erro
 	}
 
 	if int(offset) >= len(couplets) {
-		return nil, nil
+		return nil, nil, nil
 	}
 
-	if int(offset+limit) >= len(couplets) {
-		return couplets[offset:], nil
+	end := min(int(offset+limit), len(couplets))
+	if translated != nil {
+		translated = translated[offset:end]
 	}
 
-	return couplets[offset : offset+limit], nil
+	return couplets[offset:end], translated, nil
+}
+
+// alignCouplets aligns the couplets of the translation with the count couplets of the original by their order.
+// The couplets missing in the translation are nil, the extra couplets are joined into the last couplet
+func alignCouplets(count int, couplets []string) []*string {
+	aligned := make([]*string, count)
+	for idx := range min(count, len(couplets)) {
+		aligned[idx] = &couplets[idx]
+	}
+
+	if count > 0 && len(couplets) > count {
+		last := strings.Join(couplets[count-1:], "\n\n")
+		aligned[count-1] = &last
+	}
+
+	return aligned
+}
+
+// splitCouplets splits the text of the song into the couplets separated by the empty lines
+func splitCouplets(text string) []string {
+	return strings.Split(strings.ReplaceAll(text, `\n`, "\n"), "\n\n")
 }
@@ -10,11 +10,13 @@ import (
 	"strings"
 
 	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/filter"
 	"github.com/amicie-monami/music-library/pkg/httpkit"
 )
 
 type songDataGetter interface {
-	GetSongs(ctx context.Context, aggregation map[string]any) ([]*dto.SongWithDetails, error)
+	GetSongs(ctx context.Context, aggregation map[string]any) (*dto.GetSongsResponse, error)
 }
 
 // @Summary Получение данных библиотеки
@@ -26,39 +28,55 @@ type songDataGetter interface {
 // @Produce json
 // @Param limit query string false "Количество песен, которое необходимо верунть. Стандартное значение 10, предельное 1000."
 // @Param offset query string false "Смещение, необходимое для выборки определенного подмножества песен. Стандартное значение 0."
-// @Param fields query string false "Список полей, которые необходимо вернуть. Допустимые значения: [song_id, group, song, release_date, link, text]. Зачения передаются через знак ”+”,например: fields=song_id+release_date."
-// @Param filter query string false "Фильтр, с помощью которого происходит аггрегация данных. Допустимые значения: [song_id, song_name, groups, release_date, link, text]. Значения передаются через знак ”,”, например: filter=song_id=1,groups=нервы+жщ. Описание каждого параметра приведено ниже."
-// @Param (filter)song_id query string false "Параметр описывает фильтр для идентификатора песни. Поддерживает равенство на одно значение и выборку с помощью операторов сравнения: gt(>), ge(>=), le(<=), lt(<). Пример: filter=song_id=gt+2+lt+8."
-// @Param (filter)groups query string false "Параметр описывает фильтр для названия группы. Названия групп передаются через знак ”+”.Чувствителен к регистру, пробелы в названиях заменяются знаком ”_”. Пример: filter=groups=Noize_MC+мы."
-// @Param (filter)song_name query string false "Параметр описывает фильтр для названий песен. Поддерживает оператор * регулярных выражений, нечувствителен к регистру, множественные значения передаются через знак ”+”. Пример: filter=song_name=Lil\*+\*eva\*."
-// @Param (filter)release_date query string false "Параметр описывает фильтр для даты релиза песни. Поддерживает прямое равенство, операторы сравнения (см. (filter)song_id) и установку границ с помощью знака ”-”. Пример: filter=release_date=01.01.2023-05.05.2024 (start_date-end_date)."
-// @Param (filter)link query string false "Параметр описывает фильтр для ссылки на песню. Поддерживает оператор * регулярных выражений, нечувствителен к регистру, множественные значения передаются чреез знак ”+”. Пример: filter=link=\*yandex\*+\*spotify\*."
-// @Param (filter)text query string false "Параметр описывает фильтр для текста песни. Поддерживает оператор * регулярных выражений, нечувствителен к регистру, множественные значения передаются чреез знак ”+”. Пример: filter=text=\*батюшка\*+\*ленин\*."
-// @Success 200 {array} dto.GetSongsResponse "Список песен, прошедших аггрегацию данных."
+// @Param cursor query string false "Курсор страницы: значение поля next_cursor предыдущей страницы. Страница начинается сразу после последней песни предыдущей страницы, вставка новых песен не сдвигает страницы. Используется с тем же параметром sort, не совмещается с offset."
+// @Param total query string false "Режим подсчета общего количества песен, прошедших фильтр: exact (точное, по умолчанию), approx (оценка планировщика, поле total_approx) или none (не считать)."
+// @Param sort query string false "Порядок песен. Допустимые поля: [song_id, group, song, release_date, similarity], поля передаются через знак ”,”. Знак ”-” перед полем задает порядок по убыванию, суффиксы :nulls_first и :nulls_last задают положение пустых значений (по умолчанию пустые значения идут последними). Песни с одинаковыми значениями полей упорядочиваются по идентификатору. Поле similarity доступно только с нечеткими фильтрами. По умолчанию песни упорядочены по идентификатору. Пример: sort=-release_date,group."
+// @Param fields query string false "Список полей, которые необходимо вернуть. Допустимые значения: [song_id, group, song, release_date, link, text, tags]. Зачения передаются через знак ”+”,например: fields=song_id+release_date."
+// @Param filter query string false "Фильтр песен. Условие имеет вид поле+оператор+значение, условия объединяются через ”;” (И) и ”,” (ИЛИ), ”!” отрицает условие, скобки группируют условия. Операторы: == (=eq=), != (=ne=), < (=lt=), <= (=le=), > (=gt=), >= (=ge=), =in=, =out=, =like=, ~= (=fuzzy=), =all=, =null=. Значения с пробелами и служебными символами заключаются в кавычки, списки значений передаются в скобках. В случае ошибки возвращается позиция неверного символа. Пример: filter=group==\"Pink Floyd\";(release_date=ge=1970-01-01,!text=null=true). Описание каждого поля приведено ниже."
+// @Param (filter)song_id query string false "Идентификатор песни. Операторы: ==, !=, <, <=, >, >=, =in=, =out=. Пример: filter=song_id=gt=2;song_id=lt=8."
+// @Param (filter)artist_id query string false "Идентификатор исполнителя, операторы аналогичны (filter)song_id. Пример: filter=artist_id==3."
+// @Param (filter)group query string false "Название группы. Операторы: ==, != (чувствительны к регистру), =in=, =out=, =like= (оператор * регулярных выражений, нечувствителен к регистру), ~= (нечеткое совпадение, устойчивое к опечаткам). С нечетким фильтром песни возвращаются в порядке убывания сходства, значение сходства (от 0 до 1) возвращается в поле similarity. Пример: filter=group=in=(\"Noize MC\",мы),group~=metalica."
+// @Param (filter)song query string false "Название песни, операторы аналогичны (filter)group. Пример: filter=song=like=Lil*,song~=\"bohemian rapsody\"."
+// @Param (filter)album query string false "Альбомы, в которые входит песня. Операторы: ==, =in=, =out=. Пример: filter=album=in=(1,4)."
+// @Param (filter)tags query string false "Теги и жанры песни, нечувствительны к регистру. Операторы: ==, =in= (песня имеет хотя бы один из тегов), =all= (песня имеет все теги), =out= (песня не имеет ни одного из тегов). Пример: filter=tags=all=(rock,\"hard rock\")."
+// @Param (filter)release_date query string false "Дата релиза песни в формате yyyy-mm-dd или dd.mm.yyyy. Операторы аналогичны (filter)song_id, а также =null= (true - дата не указана, false - указана). Пример: filter=release_date=ge=2023-01-01;release_date=le=2024-05-05."
+// @Param (filter)link query string false "Ссылки песни, песня подходит, если подходит любая из ее ссылок. Операторы: ==, =like=, =null= (true - у песни нет ссылок). Пример: filter=link=like=*yandex*,link=like=*spotify*."
+// @Param (filter)text query string false "Текст песни. Операторы: =like=, =null=. Пример: filter=text=like=*батюшка*."
+// @Success 200 {object} dto.GetSongsResponse "Список песен, прошедших аггрегацию данных. Поле etag песни содержит ее версию для заголовка If-Match. Поле has_more показывает, есть ли следующая страница, next_cursor - курсор следующей страницы."
 // @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
 func GetSongs(repo songDataGetter) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		params, err := parseGetSongsDataQueryParams(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
 		songs, err := repo.GetSongs(r.Context(), params)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
-		slog.Info("songs have been successfully filtered", "count", len(songs))
-		responseBody := dto.GetSongsResponse{Songs: songs}
-		httpkit.Ok(w, responseBody)
+		setSongsETags(songs.Songs)
+
+		slog.Info("songs have been successfully filtered", "count", len(songs.Songs), "has_more", songs.HasMore)
+		httpkit.Ok(w, songs)
 	})
 }
 
+// setSongsETags sets the etags of the songs, so the client can update the song of the list conditionally
+func setSongsETags(songs []*dto.SongWithDetails) {
+	for _, song := range songs {
+		if song.Version > 0 {
+			song.ETag = httpkit.ETag(song.Version)
+		}
+	}
+}
+
 func parseGetSongsDataQueryParams(r *http.Request) (map[string]any, error) {
-	filterMap, err := parseGetSongsDataFilterParams(r)
+	filterNode, err := parseGetSongsDataFilterParams(r)
 	if err != nil {
 		return nil, err
 	}
@@ -78,14 +96,117 @@ func parseGetSongsDataQueryParams(r *http.Request) (map[string]any, error) {
 		return nil, err
 	}
 
+	sort, err := parseGetSongsSortParam(r, filterNode)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, total, err := parseGetSongsPageParams(r, offset)
+	if err != nil {
+		return nil, err
+	}
+
 	return map[string]any{
-		"filter": filterMap,
+		"filter": filterNode,
 		"limit":  limit,
 		"offset": offset,
 		"fields": fields,
+		"sort":   sort,
+		"cursor": cursor,
+		"total":  total,
 	}, nil
 }
 
+// parseGetSongsPageParams parses the cursor of the page and the mode of the total count.
+// The cursor can't be combined with the offset, the total takes one value from [exact, approx, none]
+func parseGetSongsPageParams(r *http.Request, offset int64) (string, string, error) {
+	return checkGetSongsPageParams(httpkit.GetStrParam("cursor", r), httpkit.GetStrParam("total", r), offset)
+}
+
+func checkGetSongsPageParams(cursor string, total string, offset int64) (string, string, error) {
+	if cursor != "" && offset != 0 {
+		return "", "", dto.NewError(400, "cursor can't be used with offset", "parseGetSongsPageParams", nil, nil)
+	}
+
+	switch total {
+	case "":
+		total = "exact"
+	case "exact", "approx", "none":
+	default:
+		details := fmt.Sprintf("total=%s, but must be one of [exact, approx, none]", total)
+		return "", "", dto.NewError(400, "invalid total param", "parseGetSongsPageParams", details, nil)
+	}
+
+	return cursor, total, nil
+}
+
+// parseGetSongsSortParam parses the comma separated sort fields [e.g. sort=-release_date:nulls_first,group].
+// The "-" prefix sets the descending order, the nulls go last unless the ":nulls_first" suffix is set.
+// The similarity field is only available with the fuzzy filters
+func parseGetSongsSortParam(r *http.Request, filterNode filter.Node) ([]model.SortField, error) {
+	sortParam := httpkit.GetStrParam("sort", r)
+	if sortParam == "" {
+		return nil, nil
+	}
+	return checkGetSongsSortFields(strings.Split(sortParam, ","), filterNode)
+}
+
+// checkGetSongsSortFields parses the sort fields [e.g. -release_date:nulls_first]
+func checkGetSongsSortFields(items []string, filterNode filter.Node) ([]model.SortField, error) {
+
+	availableValues := map[string]struct{}{
+		"song_id":      {},
+		"group":        {},
+		"song":         {},
+		"release_date": {},
+		"similarity":   {},
+	}
+
+	sort := make([]model.SortField, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+
+		var field model.SortField
+		if strings.HasPrefix(item, "-") {
+			field.Desc, item = true, item[1:]
+		}
+
+		name, nulls, hasNulls := strings.Cut(item, ":")
+		if hasNulls {
+			switch nulls {
+			case "nulls_first":
+				field.NullsFirst = true
+			case "nulls_last":
+			default:
+				details := fmt.Sprintf("%s, but nulls order must be one of [nulls_first, nulls_last]", item)
+				return nil, dto.NewError(400, "invalid sort param", "parseGetSongsSortParam", details, nil)
+			}
+		}
+
+		if _, ok := availableValues[name]; !ok {
+			details := fmt.Sprintf("%s, but must be one of [song_id, group, song, release_date, similarity]", name)
+			return nil, dto.NewError(400, "invalid sort field", "parseGetSongsSortParam", details, nil)
+		}
+
+		if _, ok := seen[name]; ok {
+			return nil, dto.NewError(400, "duplicate sort field", "parseGetSongsSortParam", name, nil)
+		}
+		seen[name] = struct{}{}
+
+		if name == "similarity" && !filter.HasOp(filterNode, filter.OpFuzzy) {
+			details := "similarity is only available with the fuzzy filters [e.g. group=fuzzy=metalica]"
+			return nil, dto.NewError(400, "invalid sort field", "parseGetSongsSortParam", details, nil)
+		}
+
+		field.Field = name
+		sort = append(sort, field)
+	}
+
+	return sort, nil
+}
+
 func parseGetSongsFieldsParam(r *http.Request) (string, error) {
 	fieldsParam := httpkit.GetStrParam("fields", r)
 	if fieldsParam == "" {
@@ -97,6 +218,12 @@ func parseGetSongsFieldsParam(r *http.Request) (string, error) {
 		return "", dto.NewError(400, "failed to decode url", "parseGetSongsFieldsParam", nil, nil)
 	}
 
+	fields := strings.Split(strings.TrimSpace(strings.ReplaceAll(decodedFieldsParam, "+", " ")), " ")
+	return checkGetSongsFields(fields)
+}
+
+// checkGetSongsFields replaces the field names with the database column names
+func checkGetSongsFields(fields []string) (string, error) {
 	availableValues := map[string]string{
 		"song_id":      "song_id",
 		"group":        "group_name",
@@ -104,64 +231,40 @@ func parseGetSongsFieldsParam(r *http.Request) (string, error) {
 		"release_date": "release_date",
 		"link":         "link",
 		"text":         "text",
+		"tags":         "tags",
 	}
 
-	fieldsParam = strings.ReplaceAll(decodedFieldsParam, "+", " ")
-	fields := strings.Split(strings.TrimSpace(fieldsParam), " ")
-
+	columns := make([]string, 0, len(fields))
 	for idx := range fields {
-		if _, ok := availableValues[fields[idx]]; !ok {
+		column, ok := availableValues[fields[idx]]
+		if !ok {
 			return "", dto.NewError(400, "unknown parameter", "parseGetSongsFieldsParam", fields[idx], nil)
 		}
 		//replace query field names with the database column names
-		fieldsParam = strings.ReplaceAll(fieldsParam, fields[idx], availableValues[fields[idx]])
+		columns = append(columns, column)
 	}
 
-	return fieldsParam, nil
+	return strings.Join(columns, " "), nil
 }
 
-func parseGetSongsDataFilterParams(r *http.Request) (map[string]any, error) {
-	filter := httpkit.GetStrParam("filter", r)
-	if filter == "" {
-		return nil, nil
-	}
-
-	decodedFilter, err := url.QueryUnescape(filter)
+// parseGetSongsDataFilterParams parses the filter query [e.g. filter=group=="Pink Floyd";release_date=ge=1970-01-01].
+// Returns nil if the filter isn't set
+func parseGetSongsDataFilterParams(r *http.Request) (filter.Node, error) {
+	filterParam, err := httpkit.GetRawStrParam("filter", r)
 	if err != nil {
-		return nil, dto.NewError(400, "failed to decode url", "parseGetSongsFieldsParam", nil, nil)
+		return nil, dto.NewError(400, "failed to decode url", "parseGetSongsDataFilterParams", nil, nil)
 	}
 
-	availableValues := map[string]struct{}{
-		"song_id":      {},
-		"song_name":    {},
-		"groups":       {},
-		"link":         {},
-		"release_date": {},
-		"text":         {},
+	if strings.TrimSpace(filterParam) == "" {
+		return nil, nil
 	}
 
-	params := strings.Split(strings.ReplaceAll(decodedFilter, "+", " "), ",")
-
-	filterMap := make(map[string]any)
-	for idx := range params {
-		param := strings.Split(params[idx], "=")
-
-		if param[0] == "song_id" {
-			// add validate for song_id parameter
-		}
-
-		if len(param) != 2 || param[1] == "" {
-			return nil, dto.NewError(400, "missing value for filter", "parseGetSongsDataFilterParams", params[idx], nil)
-		}
-
-		if _, ok := availableValues[param[0]]; !ok {
-			return nil, dto.NewError(400, "invalid filter key", "parseGetSongsDataFilterParams", param[0], nil)
-		}
-
-		filterMap[param[0]] = param[1]
+	node, err := filter.Parse(filterParam, filter.Songs)
+	if err != nil {
+		return nil, dto.NewError(400, "invalid filter", "parseGetSongsDataFilterParams", err.Error(), nil)
 	}
 
-	return filterMap, nil
+	return node, nil
 }
 
 func parseOffsetParam(r *http.Request) (int64, error) {
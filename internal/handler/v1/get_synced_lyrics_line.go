@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/amicie-monami/music-library/pkg/lrc"
+)
+
+// @Summary Получение строки текста, исполняемой в момент времени
+// @Description Метод возвращает строку синхронизированного текста, которая исполняется в момент t от начала песни, и следующую за ней строку. Строка исполняется до начала следующей строки. До начала первой строки поле line равно null.
+// @Router /songs/{id}/lyrics/line [get]
+// @Tags Songs
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param t query number true "Время от начала песни в секундах, например 93.4."
+// @Success 200 {object} dto.GetSyncedLyricsLineResponse "Строка текста, исполняемая в момент t."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное значение параметра t."
+// @Failure 404 {object} dto.Error "Песня не найдена или у песни нет синхронизированного текста."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetSyncedLyricsLine(repo syncedLyricsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		at, err := parseLyricsTimeParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		lines, err := getSyncedLyrics(r.Context(), repo, songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		response := dto.GetSyncedLyricsLineResponse{SongID: songID, AtMs: at.Milliseconds()}
+
+		idx := lrc.At(lrcLines(lines), at)
+		if idx >= 0 {
+			response.Line = lines[idx]
+		}
+		if idx+1 < len(lines) {
+			response.Next = lines[idx+1]
+		}
+
+		slog.Info("synced lyrics line has been found", "song_id", songID, "at_ms", response.AtMs, "line", idx+1)
+		httpkit.Ok(w, response)
+	})
+}
+
+// parseLyricsTimeParam parses the required time from the beginning of the song in seconds
+func parseLyricsTimeParam(r *http.Request) (time.Duration, error) {
+	param := httpkit.GetStrParam("t", r)
+	if param == "" {
+		return 0, dto.NewError(400, "missing t param", "parseLyricsTimeParam", "t param is required", nil)
+	}
+
+	seconds, err := strconv.ParseFloat(param, 64)
+	if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+		details := fmt.Sprintf("t=%s, but must be a number of seconds >= 0", param)
+		return 0, dto.NewError(400, "invalid t param", "parseLyricsTimeParam", details, nil)
+	}
+
+	return time.Duration(math.Round(seconds*1000)) * time.Millisecond, nil
+}
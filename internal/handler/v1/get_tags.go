@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type tagsGetter interface {
+	GetTags(ctx context.Context, kind string, limit int64, offset int64) ([]*dto.Tag, error)
+}
+
+// @Summary Получение списка тегов
+// @Description Метод возвращает теги и жанры библиотеки с количеством песен, отсортированные по популярности.
+// @Router /tags [get]
+// @Tags Tags
+// @Produce json
+// @Param kind query string false "Тип тегов: genre или tag. По умолчанию возвращаются все теги."
+// @Param limit query string false "Количество тегов, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества тегов. Стандартное значение 0."
+// @Success 200 {object} dto.GetTagsResponse "Список тегов."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func GetTags(repo tagsGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := httpkit.GetStrParam("kind", r)
+		if kind != "" {
+			if err := checkTagKind(kind); err != nil {
+				sendError(w, r, err)
+				return
+			}
+		}
+
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		tags, err := repo.GetTags(r.Context(), kind, limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("tags have been found", "count", len(tags))
+		httpkit.Ok(w, dto.GetTagsResponse{Tags: tags})
+	})
+}
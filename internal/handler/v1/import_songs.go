@@ -0,0 +1,420 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// importBatchSize is the number of the songs saved in one transaction
+const importBatchSize = 100
+
+type songImporter interface {
+	ImportSongs(ctx context.Context, songs []*model.ImportSong, dryRun bool) ([]*dto.ImportSongResult, error)
+}
+
+// @Summary Импорт песен
+// @Description Метод добавляет в библиотеку песни из файла. Формат файла определяется заголовком Content-Type: text/csv (первая строка - названия колонок), application/json (массив объектов) или application/x-ndjson (объект в каждой строке). Поля строки: group и song (обязательные), release_date (dd.mm.yyyy или yyyy-mm-dd), text, link. Песни сохраняются пакетами в отдельных транзакциях. Песни, которые уже есть в библиотеке или повторяются в файле, пропускаются (названия песен сравниваются без учёта регистра и лишних пробелов). Для каждой строки возвращается результат: created, skipped или failed с причиной.
+// @Router /songs/import [post]
+// @Tags Songs
+// @Accept json
+// @Accept plain
+// @Produce json
+// @Param dry_run query bool false "Проверка файла без сохранения песен. Отчет формируется так же, как при импорте."
+// @Param songs body []dto.ImportSongRow true "Песни в формате CSV, JSON или NDJSON."
+// @Success 200 {object} dto.ImportSongsResponse "Отчет об импорте: количество добавленных, пропущенных и ошибочных строк и результат каждой строки."
+// @Failure 400 {object} dto.Error "Неверный запрос, неподдерживаемый формат или некорректный заголовок CSV."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func ImportSongs(txManager transactor, repo songImporter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dryRun, err := parseImportSongsDryRunParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		reader, err := newImportReader(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		report, err := importSongs(r.Context(), txManager, repo, reader, dryRun)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("songs have been imported", "dry_run", dryRun, "created", report.Created, "skipped", report.Skipped, "failed", report.Failed)
+		httpkit.Ok(w, report)
+	})
+}
+
+func parseImportSongsDryRunParam(r *http.Request) (bool, error) {
+	dryRunParam := httpkit.GetStrParam("dry_run", r)
+	if dryRunParam == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(dryRunParam)
+	if err != nil {
+		details := fmt.Sprintf("dry_run=%s, but must be true or false", dryRunParam)
+		return false, dto.NewError(400, "invalid dry_run param", "parseImportSongsDryRunParam", details, nil)
+	}
+	return dryRun, nil
+}
+
+// errImportDryRun rolls back the transaction of the dry run batch
+var errImportDryRun = errors.New("dry run")
+
+// importSongs reads the rows of the file and saves them by batches. The invalid rows and the repeated
+// songs of the file are reported without saving. The broken file stops the import, the saved batches are kept.
+// Each batch is saved in its own transaction, the transaction of the dry run is always rolled back
+func importSongs(ctx context.Context, txManager transactor, repo songImporter, reader importReader, dryRun bool) (*dto.ImportSongsResponse, error) {
+	report := &dto.ImportSongsResponse{DryRun: dryRun, Rows: make([]*dto.ImportSongResult, 0)}
+	batch := make([]*model.ImportSong, 0, importBatchSize)
+
+	//the rows of the songs of the file by the group and the normalized song names
+	seen := make(map[[2]string]int)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		var results []*dto.ImportSongResult
+		tx := func(ctx context.Context) (err error) {
+			results, err = repo.ImportSongs(ctx, batch, dryRun)
+			if err == nil && dryRun {
+				return errImportDryRun
+			}
+			return err
+		}
+
+		if err := txManager.Do(ctx, tx); err != nil && !errors.Is(err, errImportDryRun) {
+			return err
+		}
+
+		for _, result := range results {
+			addImportResult(report, result)
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		record, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			addImportResult(report, &dto.ImportSongResult{Row: record.row, Status: model.ImportFailed, Reason: err.Error()})
+			break
+		}
+
+		song, err := newImportSongModel(record)
+		if err != nil {
+			addImportResult(report, &dto.ImportSongResult{Row: record.row, Status: model.ImportFailed, Reason: err.Error()})
+			continue
+		}
+
+		key := [2]string{song.Song.Group, model.NormalizeSongName(song.Song.Name)}
+		if row, ok := seen[key]; ok {
+			reason := fmt.Sprintf("duplicate of row %d", row)
+			addImportResult(report, &dto.ImportSongResult{Row: record.row, Status: model.ImportSkipped, Reason: reason})
+			continue
+		}
+		seen[key] = record.row
+
+		batch = append(batch, song)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	//the invalid rows are reported before the batches are saved
+	slices.SortFunc(report.Rows, func(a, b *dto.ImportSongResult) int {
+		return a.Row - b.Row
+	})
+
+	return report, nil
+}
+
+// newImportSongModel validates the row of the file
+func newImportSongModel(record *importRecord) (*model.ImportSong, error) {
+	if record.err != nil {
+		return nil, record.err
+	}
+
+	data := record.data
+	group, song := strings.TrimSpace(data.Group), strings.TrimSpace(data.Song)
+
+	switch {
+	case group == "":
+		return nil, errors.New("field group is required")
+	case song == "":
+		return nil, errors.New("field song is required")
+	case utf8.RuneCountInString(group) > 128:
+		return nil, errors.New("field group must be up to 128 characters long")
+	case utf8.RuneCountInString(song) > 32:
+		return nil, errors.New("field song must be up to 32 characters long")
+	}
+
+	importSong := &model.ImportSong{Row: record.row, Song: model.Song{Group: group, Name: song}}
+
+	if releaseDate := strings.TrimSpace(data.ReleaseDate); releaseDate != "" {
+		date, err := parseReleaseDate(releaseDate)
+		if err != nil {
+			return nil, err
+		}
+		importSong.Details.ReleaseDate = &date
+	}
+
+	if text := strings.TrimSpace(data.Text); text != "" {
+		importSong.Details.Text = &text
+	}
+
+	if strings.TrimSpace(data.Link) != "" {
+		link, fieldErr := normalizeLinkURL("link", data.Link)
+		if fieldErr != nil {
+			return nil, fmt.Errorf("field link is invalid: %v", fieldErr.Message)
+		}
+		importSong.Details.Link = &link
+	}
+
+	return importSong, nil
+}
+
+func parseReleaseDate(releaseDate string) (time.Time, error) {
+	for _, layout := range []string{"02.01.2006", "2006-01-02"} {
+		if date, err := time.Parse(layout, releaseDate); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("failed to parse release_date=%s, expected format was dd.mm.yyyy or yyyy-mm-dd", releaseDate)
+}
+
+// addImportResult adds the result of the row to the report and counts it by the status
+func addImportResult(report *dto.ImportSongsResponse, result *dto.ImportSongResult) {
+	switch result.Status {
+	case model.ImportCreated:
+		report.Created++
+	case model.ImportSkipped:
+		report.Skipped++
+	default:
+		report.Failed++
+	}
+	report.Rows = append(report.Rows, result)
+}
+
+/// ------------ Readers ------------ ///
+
+// importRecord is the row of the file, err is the error of the row format
+type importRecord struct {
+	row  int
+	data dto.ImportSongRow
+	err  error
+}
+
+// importReader reads the rows of the import file one by one
+type importReader interface {
+	// next returns the next row, io.EOF at the end of the file. The other errors mean the file
+	// is broken and can't be read further, the record has the number of the broken row
+	next() (*importRecord, error)
+}
+
+// newImportReader returns the reader of the request body by its content type
+func newImportReader(r *http.Request) (importReader, error) {
+	contentType := r.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = ""
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return newCSVImportReader(r.Body)
+	case "application/json":
+		return newJSONImportReader(r.Body)
+	case "application/x-ndjson", "application/ndjson", "application/jsonl", "application/x-jsonlines":
+		return &ndjsonImportReader{reader: bufio.NewReader(r.Body)}, nil
+	default:
+		details := fmt.Sprintf("content type=%s, but must be one of [text/csv, application/json, application/x-ndjson]", contentType)
+		return nil, dto.NewError(400, "unsupported content type", "newImportReader", details, nil)
+	}
+}
+
+// csvImportReader reads the CSV file, the first row is the header with the names of the columns
+type csvImportReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func newCSVImportReader(body io.Reader) (*csvImportReader, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, dto.NewError(400, "invalid csv header", "newCSVImportReader", err.Error(), nil)
+	}
+
+	columns := make(map[string]int, len(header))
+	for idx, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+
+		switch column {
+		case "group", "song", "release_date", "text", "link":
+		default:
+			details := fmt.Sprintf("column=%s, but must be one of [group, song, release_date, text, link]", column)
+			return nil, dto.NewError(400, "invalid csv header", "newCSVImportReader", details, nil)
+		}
+
+		if _, ok := columns[column]; ok {
+			return nil, dto.NewError(400, "invalid csv header", "newCSVImportReader", fmt.Sprintf("column=%s is repeated", column), nil)
+		}
+		columns[column] = idx
+	}
+
+	for _, column := range []string{"group", "song"} {
+		if _, ok := columns[column]; !ok {
+			return nil, dto.NewError(400, "invalid csv header", "newCSVImportReader", fmt.Sprintf("column %s is required", column), nil)
+		}
+	}
+
+	return &csvImportReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvImportReader) next() (*importRecord, error) {
+	fields, err := r.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.EOF
+	}
+
+	r.row++
+	record := &importRecord{row: r.row}
+
+	if err != nil {
+		//the row with the wrong number of fields is skipped, the other errors break the file
+		if !errors.Is(err, csv.ErrFieldCount) {
+			return record, fmt.Errorf("invalid csv: %v", err)
+		}
+		record.err = fmt.Errorf("expected %d fields, got %d", len(r.columns), len(fields))
+		return record, nil
+	}
+
+	column := func(name string) string {
+		if idx, ok := r.columns[name]; ok {
+			return fields[idx]
+		}
+		return ""
+	}
+
+	record.data = dto.ImportSongRow{
+		Group:       column("group"),
+		Song:        column("song"),
+		ReleaseDate: column("release_date"),
+		Text:        column("text"),
+		Link:        column("link"),
+	}
+	return record, nil
+}
+
+// jsonImportReader reads the JSON array of the rows
+type jsonImportReader struct {
+	decoder *json.Decoder
+	row     int
+}
+
+func newJSONImportReader(body io.Reader) (*jsonImportReader, error) {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, dto.NewError(400, "invalid json", "newJSONImportReader", "expected an array of songs", nil)
+	}
+
+	return &jsonImportReader{decoder: decoder}, nil
+}
+
+func (r *jsonImportReader) next() (*importRecord, error) {
+	if !r.decoder.More() {
+		if _, err := r.decoder.Token(); err != nil {
+			return &importRecord{row: r.row + 1}, fmt.Errorf("invalid json: %v", err)
+		}
+		return nil, io.EOF
+	}
+
+	r.row++
+	record := &importRecord{row: r.row}
+
+	if err := r.decoder.Decode(&record.data); err != nil {
+		//the value of the wrong type is read completely, so the next rows can be read
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return record, fmt.Errorf("invalid json: %v", err)
+		}
+		record.err = fmt.Errorf("invalid row: %v", err)
+	}
+
+	return record, nil
+}
+
+// ndjsonImportReader reads the JSON objects separated by the new lines, the empty lines are ignored
+type ndjsonImportReader struct {
+	reader *bufio.Reader
+	row    int
+}
+
+func (r *ndjsonImportReader) next() (*importRecord, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return &importRecord{row: r.row + 1}, fmt.Errorf("failed to read the body: %v", err)
+		}
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			r.row++
+			continue
+		}
+
+		r.row++
+		record := &importRecord{row: r.row}
+
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record.data); err != nil {
+			record.err = fmt.Errorf("invalid row: %v", err)
+		}
+
+		return record, nil
+	}
+}
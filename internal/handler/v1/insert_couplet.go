@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// @Summary Добавление куплета песни
+// @Description Метод вставляет куплет в текст песни перед куплетом с номером position, следующие куплеты сдвигаются. Без position куплет добавляется в конец текста. Текст читается и изменяется в одной транзакции под блокировкой песни.
+// @Router /songs/{id}/lyrics/couplets [post]
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param couplet body dto.InsertCoupletRequest true "Текст куплета без пустых строк и его номер, начиная с 0."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412."
+// @Param X-Author header string false "Автор изменения (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения (до 512 символов)."
+// @Success 201 {object} dto.GetSongTextResponse "Все куплеты песни после добавления. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса или заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 422 {object} dto.Error "Пустой текст куплета, текст с пустыми строками или номер больше количества куплетов."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func InsertCouplet(txManager transactor, repo coupletsEditor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var requestBody dto.InsertCoupletRequest
+		if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+			sendError(w, r, dto.NewError(400, "failed to parse couplet", "InsertCouplet", err.Error(), nil))
+			return
+		}
+
+		couplet, err := normalizeCouplet(requestBody.Text)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if requestBody.Position != nil && *requestBody.Position < 0 {
+			field := dto.FieldError{Field: "position", Message: "position must be >= 0"}
+			sendError(w, r, dto.NewValidationError("invalid couplet position", "InsertCouplet", field))
+			return
+		}
+
+		var position int
+		couplets, version, err := editCouplets(r, txManager, repo, songID, func(couplets []string) ([]string, error) {
+			position = len(couplets)
+			if requestBody.Position != nil {
+				position = *requestBody.Position
+			}
+
+			if position > len(couplets) {
+				message := fmt.Sprintf("position must be <= %d, the number of the couplets", len(couplets))
+				field := dto.FieldError{Field: "position", Message: message}
+				return nil, dto.NewValidationError("invalid couplet position", "InsertCouplet", field)
+			}
+
+			return slices.Insert(couplets, position, couplet), nil
+		})
+
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song couplet has been inserted", "song_id", songID, "position", position, "version", version)
+		w.Header().Set("ETag", httpkit.ETag(version))
+		httpkit.Created(w, dto.GetSongTextResponse{SongID: songID, Couplets: couplets})
+	})
+}
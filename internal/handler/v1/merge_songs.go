@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// maxMergedSongs is the maximum number of the duplicates merged at once
+const maxMergedSongs = 100
+
+type songsMerger interface {
+	MergeSongs(ctx context.Context, survivorID int64, duplicateIDs []int64) (*dto.SongWithDetails, error)
+}
+
+// @Summary Объединение дубликатов песни
+// @Description Метод объединяет дубликаты с песней в одной транзакции и удаляет их. Незаполненные дата релиза и текст песни берутся из дубликатов в порядке их перечисления, вместе с текстом переносятся его ревизии и синхронизация. Ссылки, теги, треки альбомов и недостающие переводы переносятся к песне. Названия исполнителя и песни дубликатов должны совпадать с названиями песни без учёта регистра и лишних пробелов.
+// @Router /songs/{id}/merge [post]
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни, которая остаётся в библиотеке."
+// @Param duplicates body dto.MergeSongsRequest true "Идентификаторы дубликатов, не более 100."
+// @Success 200 {object} dto.MergeSongsResponse "Объединённая песня и идентификаторы удалённых дубликатов."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 409 {object} dto.Error "У исполнителя остаётся другая песня с таким же названием."
+// @Failure 422 {object} dto.Error "Некорректные дубликаты: повторяются, не найдены или не являются дубликатами песни."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func MergeSongs(txManager transactor, repo songsMerger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		duplicateIDs, err := parseMergeSongsBody(r, songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var song *dto.SongWithDetails
+		tx := func(ctx context.Context) error {
+			song, err = repo.MergeSongs(ctx, songID, duplicateIDs)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("songs have been merged", "id", songID, "duplicates", duplicateIDs)
+		httpkit.Ok(w, dto.MergeSongsResponse{Song: song, Merged: duplicateIDs})
+	})
+}
+
+func parseMergeSongsBody(r *http.Request, songID int64) ([]int64, error) {
+	var data dto.MergeSongsRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse duplicates", "parseMergeSongsBody", err.Error(), nil)
+	}
+
+	if len(data.Duplicates) == 0 {
+		return nil, dto.NewValidationError("incorrect duplicates", "parseMergeSongsBody", dto.FieldError{Field: "duplicates", Message: "field duplicates is required"})
+	}
+
+	if len(data.Duplicates) > maxMergedSongs {
+		field := dto.FieldError{Field: "duplicates", Message: fmt.Sprintf("got %d duplicates, but must be <= %d", len(data.Duplicates), maxMergedSongs)}
+		return nil, dto.NewValidationError("incorrect duplicates", "parseMergeSongsBody", field)
+	}
+
+	seen := make(map[int64]struct{}, len(data.Duplicates))
+	for _, duplicateID := range data.Duplicates {
+		if duplicateID <= 0 {
+			field := dto.FieldError{Field: "duplicates", Message: fmt.Sprintf("song_id=%d but must me > 0", duplicateID)}
+			return nil, dto.NewValidationError("incorrect duplicates", "parseMergeSongsBody", field)
+		}
+
+		if duplicateID == songID {
+			field := dto.FieldError{Field: "duplicates", Message: fmt.Sprintf("song_id=%d is the merged song", duplicateID)}
+			return nil, dto.NewValidationError("incorrect duplicates", "parseMergeSongsBody", field)
+		}
+
+		if _, ok := seen[duplicateID]; ok {
+			field := dto.FieldError{Field: "duplicates", Message: fmt.Sprintf("song_id=%d is repeated", duplicateID)}
+			return nil, dto.NewValidationError("incorrect duplicates", "parseMergeSongsBody", field)
+		}
+		seen[duplicateID] = struct{}{}
+	}
+
+	return data.Duplicates, nil
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type lyricsRestorer interface {
+	LockSongVersion(ctx context.Context, id int64) (int64, error)
+	SetChangeAuthor(ctx context.Context, author string, reason string) error
+	GetLyricsRevision(ctx context.Context, songID int64, revision int64) (*dto.LyricsRevision, error)
+	GetLatestLyricsRevision(ctx context.Context, songID int64) (*dto.LyricsRevision, error)
+	UpdateSongDetails(ctx context.Context, details *model.SongDetail) error
+}
+
+// @Summary Восстановление ревизии текста песни
+// @Description Метод заменяет текст песни текстом ревизии. Восстановление не удаляет историю: оно записывается как новая ревизия, если текст песни отличается от текста ревизии.
+// @Router /songs/{id}/lyrics/revisions/{rev}/restore [post]
+// @Tags Songs
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param rev path int true "Номер ревизии, которую необходимо восстановить."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412."
+// @Param X-Author header string false "Автор изменения (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения (до 512 символов). По умолчанию restored revision {rev}."
+// @Success 200 {object} dto.RestoreLyricsRevisionResponse "Текст восстановлен, возвращается последняя ревизия текста. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный номер ревизии или заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня или ревизия не найдена."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func RestoreLyricsRevision(txManager transactor, repo lyricsRestorer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		revisionNumber, err := parsePathVarRevision(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		author, reason, err := parseChangeAuthor(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if reason == "" {
+			reason = fmt.Sprintf("restored revision %d", revisionNumber)
+		}
+
+		var (
+			version int64
+			latest  *dto.LyricsRevision
+		)
+
+		tx := func(ctx context.Context) error {
+			current, err := repo.LockSongVersion(ctx, songID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkIfMatch(r, current); err != nil {
+				return err
+			}
+
+			revision, err := repo.GetLyricsRevision(ctx, songID, revisionNumber)
+			if err != nil {
+				return err
+			}
+
+			if err := repo.SetChangeAuthor(ctx, author, reason); err != nil {
+				return err
+			}
+
+			//the revision without the text has cleared the lyrics
+			songDetails := &model.SongDetail{SongID: songID, Text: revision.Text}
+			if revision.Text == nil {
+				songDetails.Clear = []string{model.SongDetailText}
+			}
+
+			if err := repo.UpdateSongDetails(ctx, songDetails); err != nil {
+				return err
+			}
+
+			if latest, err = repo.GetLatestLyricsRevision(ctx, songID); err != nil {
+				return err
+			}
+
+			version, err = repo.LockSongVersion(ctx, songID)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("lyrics revision has been restored", "song_id", songID, "revision", revisionNumber, "latest", latest.Revision)
+		w.Header().Set("ETag", httpkit.ETag(version))
+		httpkit.Ok(w, dto.RestoreLyricsRevisionResponse{SongID: songID, Revision: latest})
+	})
+}
+
+func parsePathVarRevision(r *http.Request) (int64, error) {
+	return parsePathVarID(r, "rev", "revision")
+}
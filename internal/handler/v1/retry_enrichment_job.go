@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type enrichmentJobRetrier interface {
+	Retry(ctx context.Context, songID int64) (*dto.EnrichmentJob, error)
+}
+
+// @Summary Повторное обогащение данных песни
+// @Description Метод ставит задачу обогащения данных песни в очередь со сброшенным счетчиком попыток. Задачи в статусе running не перезапускаются.
+// @Router /songs/{id}/enrichment/retry [post]
+// @Tags Enrichment
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Success 200 {object} dto.GetEnrichmentJobResponse "Состояние задачи после постановки в очередь."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный идентификатор."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 409 {object} dto.Error "Задача выполняется."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func RetryEnrichmentJob(repo enrichmentJobRetrier) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		job, err := repo.Retry(r.Context(), songID)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("enrichment job has been requeued", "song_id", songID)
+		httpkit.Ok(w, dto.GetEnrichmentJobResponse{Job: job})
+	})
+}
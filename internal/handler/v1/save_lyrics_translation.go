@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/gorilla/mux"
+)
+
+type lyricsTranslationSaver interface {
+	SaveTranslation(ctx context.Context, translation *model.LyricsTranslation) (*dto.LyricsTranslation, bool, error)
+}
+
+// @Summary Создание или изменение перевода текста песни
+// @Description Метод сохраняет перевод текста песни на язык lang. Если перевод на этот язык уже есть, он заменяется. Куплеты перевода разделяются пустыми строками так же, как куплеты оригинала, и сопоставляются с ними по порядку.
+// @Router /songs/{id}/translations/{lang} [put]
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param lang path string true "Язык перевода (BCP 47, например en или pt-BR)."
+// @Param translation body dto.SaveLyricsTranslationRequest true "Текст перевода."
+// @Param X-Author header string false "Автор перевода (до 128 символов). По умолчанию anonymous."
+// @Success 200 {object} dto.SaveLyricsTranslationResponse "Перевод изменён."
+// @Success 201 {object} dto.SaveLyricsTranslationResponse "Перевод создан."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный язык, тело запроса или заголовок автора."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 422 {object} dto.Error "Пустой текст перевода."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func SaveLyricsTranslation(txManager transactor, repo lyricsTranslationSaver) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		lang, err := parseLanguageTag("lang", mux.Vars(r)["lang"])
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		author, _, err := parseChangeAuthor(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		translation, err := parseSaveLyricsTranslationBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		translation.SongID, translation.Lang, translation.Author = songID, lang, author
+		var (
+			saved   *dto.LyricsTranslation
+			created bool
+		)
+		tx := func(ctx context.Context) error {
+			saved, created, err = repo.SaveTranslation(ctx, translation)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("lyrics translation has been saved", "song_id", songID, "lang", lang, "created", created)
+		responseBody := dto.SaveLyricsTranslationResponse{SongID: songID, Translation: saved}
+		if created {
+			httpkit.Created(w, responseBody)
+			return
+		}
+		httpkit.Ok(w, responseBody)
+	})
+}
+
+func parseSaveLyricsTranslationBody(r *http.Request) (*model.LyricsTranslation, error) {
+	var requestBody dto.SaveLyricsTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		return nil, dto.NewError(400, "failed to parse translation", "parseSaveLyricsTranslationBody", err.Error(), nil)
+	}
+
+	if strings.TrimSpace(requestBody.Text) == "" {
+		field := dto.FieldError{Field: "text", Message: "text must be a non-empty string"}
+		return nil, dto.NewValidationError("missing the text of the translation", "parseSaveLyricsTranslationBody", field)
+	}
+
+	return &model.LyricsTranslation{Text: requestBody.Text}, nil
+}
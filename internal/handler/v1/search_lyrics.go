@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// defaultSearchLang is the search configuration of the requests without the lang param,
+// the russian configuration stems the english words as well
+const defaultSearchLang = "russian"
+
+type lyricsSearcher interface {
+	SearchLyrics(ctx context.Context, q string, lang string, limit int64, offset int64) ([]*dto.LyricsSearchResult, error)
+}
+
+// @Summary Полнотекстовый поиск по текстам песен
+// @Description Метод возвращает песни, тексты которых соответствуют запросу, в порядке релевантности. Для каждой песни возвращается наиболее подходящий куплет, найденные слова выделены тегом <b>. Запрос поддерживает синтаксис веб-поиска: фразы в кавычках, оператор or и исключение слов знаком "-".
+// @Router /search [get]
+// @Tags Search
+// @Produce json
+// @Param q query string true "Поисковый запрос, не более 256 символов. Пример: q=\"red door\" -black."
+// @Param lang query string false "Языковая конфигурация поиска: russian, english или simple (без учета словоформ). Стандартное значение russian."
+// @Param limit query string false "Количество песен, которое необходимо верунть. Стандартное значение 10, предельное 1000."
+// @Param offset query string false "Смещение, необходимое для выборки определенного подмножества песен. Стандартное значение 0."
+// @Success 200 {object} dto.SearchLyricsResponse "Найденные песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func SearchLyrics(repo lyricsSearcher) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q, lang, err := parseSearchLyricsParams(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		limit, err := parseLimitParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		offset, err := parseOffsetParam(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		results, err := repo.SearchLyrics(r.Context(), q, lang, limit, offset)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("lyrics search has been performed", "q", q, "lang", lang, "count", len(results))
+		httpkit.Ok(w, dto.SearchLyricsResponse{Query: q, Lang: lang, Results: results})
+	})
+}
+
+func parseSearchLyricsParams(r *http.Request) (string, string, error) {
+	q := strings.TrimSpace(httpkit.GetStrParam("q", r))
+	if q == "" {
+		return "", "", dto.NewError(400, "q param is required", "parseSearchLyricsParams", nil, nil)
+	}
+
+	if utf8.RuneCountInString(q) > 256 {
+		return "", "", dto.NewError(400, "invalid q param", "parseSearchLyricsParams", "q must be up to 256 characters long", nil)
+	}
+
+	lang := httpkit.GetStrParam("lang", r)
+	switch lang {
+	case "":
+		lang = defaultSearchLang
+	case "russian", "english", "simple":
+	default:
+		details := fmt.Sprintf("lang=%s, but must be one of [russian, english, simple]", lang)
+		return "", "", dto.NewError(400, "invalid lang param", "parseSearchLyricsParams", details, nil)
+	}
+
+	return q, lang, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/filter"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+// @Summary Поиск песен по фильтру
+// @Description Метод аналогичен методу GET /songs, но принимает фильтр в теле запроса в виде JSON-дерева, что позволяет передавать длинные фильтры и описывать вложенные логические выражения. Узел дерева - один из объектов: {"and": [узлы]}, {"or": [узлы]}, {"not": узел} или условие {"field": поле, "op": оператор, "value": значение или список значений}. Поля и операторы (eq, ne, lt, le, gt, ge, in, out, like, fuzzy, all, null) аналогичны параметру filter метода GET /songs. В случае ошибки возвращается путь к неверному узлу, например filter.and[1].op.
+// @Router /songs/search [post]
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Param search body dto.SearchSongsRequest true "Фильтр, порядок (sort), поля (fields) и параметры пагинации (limit, offset, cursor, total), см. GET /songs. Пример: {\"filter\": {\"and\": [{\"field\": \"group\", \"op\": \"in\", \"value\": [\"ping\", \"pong\"]}, {\"not\": {\"field\": \"text\", \"op\": \"null\", \"value\": true}}]}, \"sort\": [\"-release_date\"], \"limit\": 20}"
+// @Success 200 {object} dto.GetSongsResponse "Список песен, прошедших фильтр."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса, поля или сортировка."
+// @Failure 422 {object} dto.Error "Некорректный фильтр, limit или offset, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func SearchSongs(repo songDataGetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, err := parseSearchSongsBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		songs, err := repo.GetSongs(r.Context(), params)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		setSongsETags(songs.Songs)
+
+		slog.Info("songs have been successfully searched", "count", len(songs.Songs), "has_more", songs.HasMore)
+		httpkit.Ok(w, songs)
+	})
+}
+
+// parseSearchSongsBody parses the search request into the aggregation of the songs list, see parseGetSongsDataQueryParams
+func parseSearchSongsBody(r *http.Request) (map[string]any, error) {
+	var data dto.SearchSongsRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse search data", "parseSearchSongsBody", err.Error(), nil)
+	}
+
+	var filterNode filter.Node
+	if len(data.Filter) != 0 && string(data.Filter) != "null" {
+		node, err := filter.ParseJSON(data.Filter, filter.Songs)
+		if err != nil {
+			field := dto.FieldError{Field: "filter", Message: err.Error()}
+			if filterErr, ok := err.(*filter.Error); ok && filterErr.Path != "" {
+				field = dto.FieldError{Field: filterErr.Path, Message: filterErr.Message}
+			}
+			return nil, dto.NewValidationError("invalid filter", "parseSearchSongsBody", field)
+		}
+		filterNode = node
+	}
+
+	if data.Limit == 0 {
+		data.Limit = 10
+	}
+
+	if data.Limit < 0 {
+		field := dto.FieldError{Field: "limit", Message: fmt.Sprintf("limit=%d, but must be >= 1", data.Limit)}
+		return nil, dto.NewValidationError("invalid limit param", "parseSearchSongsBody", field)
+	}
+
+	if data.Limit > 1000 {
+		data.Limit = 1000
+	}
+
+	if data.Offset < 0 {
+		field := dto.FieldError{Field: "offset", Message: fmt.Sprintf("offset=%d, but param must be >= 0", data.Offset)}
+		return nil, dto.NewValidationError("invalid offset param", "parseSearchSongsBody", field)
+	}
+
+	var fields string
+	if len(data.Fields) != 0 {
+		columns, err := checkGetSongsFields(data.Fields)
+		if err != nil {
+			return nil, err
+		}
+		fields = columns
+	}
+
+	sort, err := checkGetSongsSortFields(data.Sort, filterNode)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, total, err := checkGetSongsPageParams(data.Cursor, data.Total, data.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]any{
+		"filter": filterNode,
+		"limit":  data.Limit,
+		"offset": data.Offset,
+		"fields": fields,
+		"sort":   sort,
+		"cursor": cursor,
+		"total":  total,
+	}, nil
+}
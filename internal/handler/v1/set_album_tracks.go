@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumTracksSetter interface {
+	SetTracks(ctx context.Context, albumID int64, songIDs []int64) error
+}
+
+// @Summary Изменение списка треков альбома
+// @Description Метод заменяет список треков альбома. Порядок треков соответствует порядку идентификаторов песен в запросе, пустой список очищает альбом.
+// @Router /albums/{id}/tracks [put]
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор альбома."
+// @Param tracks body dto.SetAlbumTracksRequest true "Идентификаторы песен в порядке следования."
+// @Success 200 {string} string "Список треков обновлен, нет данных в теле ответа."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Альбом не найден."
+// @Failure 422 {object} dto.Error "Некорректный список треков: повторяющиеся или не найденные песни."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func SetAlbumTracks(txManager transactor, repo albumTracksSetter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		albumID, err := parsePathVarAlbumID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		songIDs, err := parseSetAlbumTracksBody(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		tx := func(ctx context.Context) error {
+			return repo.SetTracks(ctx, albumID, songIDs)
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("album tracks have been updated", "id", albumID, "count", len(songIDs))
+		httpkit.Ok(w, nil)
+	})
+}
+
+func parseSetAlbumTracksBody(r *http.Request) ([]int64, error) {
+	var data dto.SetAlbumTracksRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse album tracks", "parseSetAlbumTracksBody", err.Error(), nil)
+	}
+
+	if data.Songs == nil {
+		return nil, dto.NewValidationError("incorrect album tracks", "parseSetAlbumTracksBody", dto.FieldError{Field: "songs", Message: "field songs is required"})
+	}
+
+	seen := make(map[int64]struct{}, len(data.Songs))
+	for _, songID := range data.Songs {
+		if songID <= 0 {
+			field := dto.FieldError{Field: "songs", Message: fmt.Sprintf("song_id=%d but must me > 0", songID)}
+			return nil, dto.NewValidationError("incorrect album tracks", "parseSetAlbumTracksBody", field)
+		}
+
+		if _, ok := seen[songID]; ok {
+			field := dto.FieldError{Field: "songs", Message: fmt.Sprintf("song_id=%d is repeated", songID)}
+			return nil, dto.NewValidationError("incorrect album tracks", "parseSetAlbumTracksBody", field)
+		}
+		seen[songID] = struct{}{}
+	}
+
+	return data.Songs, nil
+}
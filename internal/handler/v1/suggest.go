@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/pkg/cache"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type suggester interface {
+	Suggest(ctx context.Context, kind string, prefix string, limit int64) ([]*dto.Suggestion, error)
+}
+
+// SuggestCache caches the suggestions of the hot prefixes
+type SuggestCache = cache.Cache[string, []*dto.Suggestion]
+
+// @Summary Автодополнение названий групп и песен
+// @Description Метод возвращает группы или песни, названия которых начинаются с префикса, без учета регистра. Сначала идут точные совпадения, затем популярные: группы с большим количеством песен и песни, доступные на большем количестве платформ, затем более короткие названия. Ответы для частых префиксов кэшируются.
+// @Router /suggest [get]
+// @Tags Search
+// @Produce json
+// @Param prefix query string true "Начало названия, от 1 до 64 символов."
+// @Param kind query string false "Тип подсказок: group или song. Стандартное значение group."
+// @Param limit query string false "Количество подсказок. Стандартное значение 5, предельное 20."
+// @Success 200 {object} dto.SuggestResponse "Список подсказок."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func Suggest(repo suggester, suggestCache *SuggestCache) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind, prefix, limit, err := parseSuggestParams(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		key := fmt.Sprintf("%s:%d:%s", kind, limit, strings.ToLower(prefix))
+		if suggestions, ok := suggestCache.Get(key); ok {
+			slog.Debug("suggestions have been found in the cache", "kind", kind, "prefix", prefix)
+			httpkit.Ok(w, dto.SuggestResponse{Suggestions: suggestions})
+			return
+		}
+
+		suggestions, err := repo.Suggest(r.Context(), kind, prefix, limit)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		suggestCache.Set(key, suggestions)
+
+		slog.Debug("suggestions have been found", "kind", kind, "prefix", prefix, "count", len(suggestions))
+		httpkit.Ok(w, dto.SuggestResponse{Suggestions: suggestions})
+	})
+}
+
+func parseSuggestParams(r *http.Request) (string, string, int64, error) {
+	prefix := strings.TrimSpace(httpkit.GetStrParam("prefix", r))
+	if prefix == "" || utf8.RuneCountInString(prefix) > 64 {
+		details := fmt.Sprintf("prefix=%s, but must be 1-64 characters long", prefix)
+		return "", "", 0, dto.NewError(400, "invalid prefix param", "parseSuggestParams", details, nil)
+	}
+
+	kind := httpkit.GetStrParam("kind", r)
+	switch kind {
+	case "":
+		kind = "group"
+	case "group", "song":
+	default:
+		details := fmt.Sprintf("kind=%s, but must be one of [group, song]", kind)
+		return "", "", 0, dto.NewError(400, "invalid kind param", "parseSuggestParams", details, nil)
+	}
+
+	limit := int64(5)
+	if limitParam := httpkit.GetStrParam("limit", r); limitParam != "" {
+		value, err := strconv.ParseInt(limitParam, 10, 64)
+		if err != nil || value < 1 || value > 20 {
+			details := fmt.Sprintf("limit=%s, but must be 1-20", limitParam)
+			return "", "", 0, dto.NewError(400, "invalid limit param", "parseSuggestParams", details, nil)
+		}
+		limit = value
+	}
+
+	return kind, prefix, limit, nil
+}
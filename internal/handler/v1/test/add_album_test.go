@@ -0,0 +1,56 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAlbum(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		Code        int
+	}{
+		{
+			Description: "Valid request body",
+			ReqBody:     map[string]any{"title": "The Dark Side of the Moon", "release_date": "01.03.1973", "cover_link": "https://covers.com/1"},
+			Code:        http.StatusCreated,
+		},
+		{
+			Description: "Only title",
+			ReqBody:     map[string]any{"title": "Wish You Were Here"},
+			Code:        http.StatusCreated,
+		},
+		{
+			Description: "Title field is missing",
+			ReqBody:     map[string]any{"release_date": "01.03.1973"},
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid release date",
+			ReqBody:     map[string]any{"title": "Animals", "release_date": "1977-01-23"},
+			Code:        http.StatusUnprocessableEntity,
+		},
+	}
+
+	addAlbumHandler := handler.AddAlbum(&mock.AlbumRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			rr := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/api/v1/albums", bytes.NewBuffer(body))
+
+			addAlbumHandler.ServeHTTP(rr, request)
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddArtist(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		Code        int
+	}{
+		{
+			Description: "Valid request body",
+			ReqBody:     map[string]any{"name": "Pink Floyd"},
+			Code:        http.StatusCreated,
+		},
+		{
+			Description: "Name field is missing",
+			ReqBody:     map[string]any{},
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Artist already exists",
+			ReqBody:     map[string]any{"name": mock.ExistingArtistName},
+			Code:        http.StatusConflict,
+		},
+	}
+
+	addArtistHandler := handler.AddArtist(&mock.ArtistRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			rr := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/api/v1/artists", bytes.NewBuffer(body))
+
+			addArtistHandler.ServeHTTP(rr, request)
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
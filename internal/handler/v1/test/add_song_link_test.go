@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSongLink(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		SongID      int64
+		Code        int
+		Platform    string
+	}{
+		{
+			Description: "Spotify link",
+			ReqBody:     map[string]any{"url": "https://open.spotify.com/track/12"},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusCreated,
+			Platform:    model.PlatformSpotify,
+		},
+		{
+			Description: "YouTube link without a scheme",
+			ReqBody:     map[string]any{"url": "www.youtube.com/watch?v=12", "primary": true},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusCreated,
+			Platform:    model.PlatformYouTube,
+		},
+		{
+			Description: "Unknown platform",
+			ReqBody:     map[string]any{"url": "https://bandcamp.com/track/12"},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusCreated,
+			Platform:    model.PlatformOther,
+		},
+		{
+			Description: "Missing url",
+			ReqBody:     map[string]any{"primary": true},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Unsupported scheme",
+			ReqBody:     map[string]any{"url": "ftp://music.apple.com/track/12"},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song not found",
+			ReqBody:     map[string]any{"url": "https://soundcloud.com/track/12"},
+			SongID:      404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	addSongLinkHandler := handler.AddSongLink(&mock.TxManager{}, &mock.LinkRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("POST", "/api/v1/songs/{id}/links", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			addSongLinkHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+
+			if tc.Code == http.StatusCreated {
+				var response dto.GetSongLinksResponse
+				json.NewDecoder(rr.Body).Decode(&response)
+
+				links := response.Links
+				if assert.NotEmpty(t, links) {
+					assert.Equal(t, tc.Platform, links[len(links)-1].Platform)
+				}
+			}
+		})
+	}
+}
@@ -26,27 +26,32 @@ func TestAddSong(t *testing.T) {
 		{
 			Description: "Group field is missing",
 			ReqBody:     map[string]any{"Song": "Song"},
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Group field has zero value",
 			ReqBody:     map[string]any{"Group": "", "Song": "Song"},
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Song field is missing",
 			ReqBody:     map[string]any{"Group": "Group"},
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Song field has zero value",
 			ReqBody:     map[string]any{"Group": "Group", "Song": ""},
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Song and group fields have zero value",
 			ReqBody:     map[string]any{"Group": "", "Song": ""},
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song already exists",
+			ReqBody:     map[string]any{"Group": "Group", "Song": mock.ExistingSongName},
+			Code:        http.StatusConflict,
 		},
 	}
 
@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachSongTags(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		SongID      int64
+		Code        int
+		Tags        []string
+	}{
+		{
+			Description: "Tags are normalized",
+			ReqBody:     map[string]any{"tags": []string{" Rock", "rock", "80s"}, "kind": "genre"},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusOK,
+			Tags:        []string{"rock", "80s"},
+		},
+		{
+			Description: "Tags field is missing",
+			ReqBody:     map[string]any{},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Empty tag name",
+			ReqBody:     map[string]any{"tags": []string{"  "}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid tag kind",
+			ReqBody:     map[string]any{"tags": []string{"rock"}, "kind": "mood"},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song not found",
+			ReqBody:     map[string]any{"tags": []string{"rock"}},
+			SongID:      404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	attachSongTagsHandler := handler.AttachSongTags(&mock.TxManager{}, &mock.TagRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("POST", "/api/v1/songs/{id}/tags", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			attachSongTagsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+
+			if tc.Tags != nil {
+				var response dto.GetSongTagsResponse
+				json.NewDecoder(rr.Body).Decode(&response)
+
+				names := make([]string, 0, len(response.Tags))
+				for _, tag := range response.Tags {
+					names = append(names, tag.Name)
+				}
+				assert.Equal(t, tc.Tags, names)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteArtist(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ArtistID    int64
+		Code        int
+	}{
+		{
+			Description: "Artist without songs",
+			ArtistID:    mock.ValidArtistID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Artist has songs",
+			ArtistID:    mock.ArtistIDWithSongs,
+			Code:        http.StatusConflict,
+		},
+		{
+			Description: "Artist doesn't exist",
+			ArtistID:    404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	deleteArtistHandler := handler.DeleteArtist(&mock.ArtistRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("DELETE", "/api/v1/artists/{id}", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.ArtistID)})
+
+			rr := httptest.NewRecorder()
+
+			deleteArtistHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
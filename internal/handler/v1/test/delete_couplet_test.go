@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCouplet(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		N           string
+		Code        int
+		Text        string
+	}{
+		{
+			Description: "First couplet",
+			SongID:      mock.ValidSongID,
+			N:           "0",
+			Code:        http.StatusOK,
+			Text:        "second couplet",
+		},
+		{
+			Description: "Couplet not found",
+			SongID:      mock.ValidSongID,
+			N:           "5",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid index",
+			SongID:      mock.ValidSongID,
+			N:           "abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			N:           "0",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("DELETE", "/api/v1/songs/{id}/lyrics/couplets/{n}", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID), "n": tc.N})
+
+			repo := &mock.SongRepo{}
+			rr := httptest.NewRecorder()
+
+			handler.DeleteCouplet(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			if tc.Text != "" {
+				assert.Equal(t, tc.Text, *repo.UpdatedDetails.Text)
+			}
+		})
+	}
+}
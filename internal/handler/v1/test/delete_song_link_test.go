@@ -0,0 +1,56 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSongLink(t *testing.T) {
+	testCases := []struct {
+		Description string
+		LinkID      string
+		Code        int
+	}{
+		{
+			Description: "Valid link id",
+			LinkID:      fmt.Sprintf("%d", mock.ValidLinkID),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid link id",
+			LinkID:      "abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Link not found",
+			LinkID:      "404",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	deleteSongLinkHandler := handler.DeleteSongLink(&mock.TxManager{}, &mock.LinkRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("DELETE", "/api/v1/songs/{id}/links/{link_id}", nil)
+
+			request = mux.SetURLVars(request, map[string]string{
+				"id":      fmt.Sprintf("%d", mock.ValidSongID),
+				"link_id": tc.LinkID,
+			})
+
+			rr := httptest.NewRecorder()
+
+			deleteSongLinkHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -16,6 +16,7 @@ func TestDeleteSong(t *testing.T) {
 	testCases := []struct {
 		Description string
 		SongID      int64
+		IfMatch     string
 		Code        int
 	}{
 		{
@@ -26,16 +27,28 @@ func TestDeleteSong(t *testing.T) {
 		{
 			Description: "Song doesn't exist",
 			SongID:      489,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusNotFound,
 		},
 		{
 			Description: "Invalid song id",
 			SongID:      -1,
 			Code:        http.StatusBadRequest,
 		},
+		{
+			Description: "Current etag",
+			SongID:      mock.ValidSongID,
+			IfMatch:     fmt.Sprintf(`"%d"`, mock.ValidSongVersion),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Stale etag",
+			SongID:      mock.ValidSongID,
+			IfMatch:     `"1", "2"`,
+			Code:        http.StatusPreconditionFailed,
+		},
 	}
 
-	deleteSongHandler := handler.DeleteSong(&mock.SongRepo{})
+	deleteSongHandler := handler.DeleteSong(&mock.TxManager{}, &mock.SongRepo{})
 
 	for _, tc := range testCases {
 		t.Run(tc.Description, func(t *testing.T) {
@@ -45,6 +58,9 @@ func TestDeleteSong(t *testing.T) {
 			request := httptest.NewRequest("DELETE", "/api/v1/songs/id", nil)
 
 			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+			if tc.IfMatch != "" {
+				request.Header.Set("If-Match", tc.IfMatch)
+			}
 
 			deleteSongHandler.ServeHTTP(rr, request)
 
@@ -0,0 +1,61 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetachSongTags(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		SongID      int64
+		Code        int
+	}{
+		{
+			Description: "Valid request body",
+			ReqBody:     map[string]any{"tags": []string{"rock"}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty tags",
+			ReqBody:     map[string]any{"tags": []string{}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song not found",
+			ReqBody:     map[string]any{"tags": []string{"rock"}},
+			SongID:      404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	detachSongTagsHandler := handler.DetachSongTags(&mock.TxManager{}, &mock.TagRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("DELETE", "/api/v1/songs/{id}/tags", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			detachSongTagsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/amicie-monami/music-library/pkg/middleware"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorBody(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Handler     http.Handler
+		Request     *http.Request
+		Code        int
+		Kind        string
+		Fields      []dto.FieldError
+	}{
+		{
+			Description: "Malformed body",
+			Handler:     handler.AddSong(&mock.SongRepo{}),
+			Request:     httptest.NewRequest("POST", "/api/v1/songs", bytes.NewBufferString("{")),
+			Code:        http.StatusBadRequest,
+			Kind:        dto.KindBadRequest,
+		},
+		{
+			Description: "Invalid fields",
+			Handler:     handler.AddSong(&mock.SongRepo{}),
+			Request:     httptest.NewRequest("POST", "/api/v1/songs", bytes.NewBufferString("{}")),
+			Code:        http.StatusUnprocessableEntity,
+			Kind:        dto.KindValidation,
+			Fields: []dto.FieldError{
+				{Field: "group", Message: "field group is required"},
+				{Field: "song", Message: "field song is required"},
+			},
+		},
+		{
+			Description: "Conflict",
+			Handler:     handler.AddSong(&mock.SongRepo{}),
+			Request:     httptest.NewRequest("POST", "/api/v1/songs", bytes.NewBufferString(`{"group": "Muse", "song": "Existing"}`)),
+			Code:        http.StatusConflict,
+			Kind:        dto.KindConflict,
+		},
+		{
+			Description: "Not found",
+			Handler:     handler.GetArtist(&mock.ArtistRepo{}),
+			Request:     mux.SetURLVars(httptest.NewRequest("GET", "/api/v1/artists/404", nil), map[string]string{"id": "404"}),
+			Code:        http.StatusNotFound,
+			Kind:        dto.KindNotFound,
+		},
+		{
+			Description: "Invalid filter path",
+			Handler:     handler.SearchSongs(&mock.SongRepo{}),
+			Request:     httptest.NewRequest("POST", "/api/v1/songs/search", bytes.NewBufferString(`{"filter": {"and": [{"field": "song", "op": "near", "value": "Uprising"}]}}`)),
+			Code:        http.StatusUnprocessableEntity,
+			Kind:        dto.KindValidation,
+			Fields:      []dto.FieldError{{Field: "filter.and[0].op", Message: "unknown operator 'near'"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+
+			tc.Handler.ServeHTTP(rr, tc.Request)
+
+			require.Equal(t, tc.Code, rr.Code)
+
+			var body dto.Error
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+			assert.Equal(t, tc.Kind, body.Kind)
+			assert.NotEmpty(t, body.Message)
+			assert.Equal(t, tc.Fields, body.Fields)
+		})
+	}
+}
+
+func TestProblemBody(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Accept      string
+		Problem     bool
+	}{
+		{Description: "Default body", Accept: "", Problem: false},
+		{Description: "Json body", Accept: "application/json", Problem: false},
+		{Description: "Problem body", Accept: "application/problem+json", Problem: true},
+		{Description: "Preferred problem body", Accept: "application/json;q=0.5, application/problem+json", Problem: true},
+	}
+
+	addSongHandler := middleware.RequestID(handler.AddSong(&mock.SongRepo{}))
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/songs", bytes.NewBufferString(`{"song": "Uprising"}`))
+			request.Header.Set(middleware.RequestIDHeader, "request-12")
+			if tc.Accept != "" {
+				request.Header.Set("Accept", tc.Accept)
+			}
+
+			rr := httptest.NewRecorder()
+			addSongHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+			if !tc.Problem {
+				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+				var body dto.Error
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				assert.Equal(t, "incorrect song data", body.Message)
+				return
+			}
+
+			assert.Equal(t, "application/problem+json", rr.Header().Get("Content-Type"))
+
+			var problem dto.Problem
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&problem))
+			assert.Equal(t, dto.Problem{
+				Type:     "/problems/validation_failed",
+				Title:    "Unprocessable Entity",
+				Status:   http.StatusUnprocessableEntity,
+				Detail:   "incorrect song data",
+				Instance: "urn:request-id:request-12",
+				Code:     dto.KindValidation,
+				Errors:   []dto.FieldError{{Field: "group", Message: "field group is required"}},
+			}, problem)
+		})
+	}
+}
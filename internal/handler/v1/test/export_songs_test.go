@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSongs(t *testing.T) {
+	releaseDate, text := "2009-09-07", "They will not force us,\nthey will stop \"degrading\" us"
+	songs := []*dto.SongWithDetails{
+		{ID: 1, Group: "Muse", Title: "Uprising", ReleaseDate: &releaseDate, Text: &text, Tags: dto.StringList{"rock", "alt rock"}},
+		{ID: 2, Group: "Muse", Title: "Madness"},
+	}
+
+	testCases := []struct {
+		Description string
+		QueryParams string
+		Songs       []*dto.SongWithDetails
+		Code        int
+		ContentType string
+		Body        string
+	}{
+		{
+			Description: "CSV with default fields",
+			Songs:       songs,
+			Code:        http.StatusOK,
+			ContentType: "text/csv; charset=utf-8",
+			Body: "song_id,group,song,release_date,link,text\n" +
+				"1,Muse,Uprising,2009-09-07,,\"They will not force us,\nthey will stop \"\"degrading\"\" us\"\n" +
+				"2,Muse,Madness,,,\n",
+		},
+		{
+			Description: "CSV with fields",
+			QueryParams: "fields=song+tags&filter=group==Muse",
+			Songs:       songs,
+			Code:        http.StatusOK,
+			ContentType: "text/csv; charset=utf-8",
+			Body:        "song,tags\nUprising,rock|alt rock\nMadness,\n",
+		},
+		{
+			Description: "NDJSON",
+			QueryParams: "format=ndjson&fields=song_id+song",
+			Songs:       songs[1:],
+			Code:        http.StatusOK,
+			ContentType: "application/x-ndjson",
+			Body:        "{\"song_id\":2,\"group\":\"Muse\",\"song\":\"Madness\"}\n",
+		},
+		{
+			Description: "Empty JSON",
+			QueryParams: "format=json",
+			Code:        http.StatusOK,
+			ContentType: "application/json",
+			Body:        "[]\n",
+		},
+		{
+			Description: "Invalid format",
+			QueryParams: "format=xml",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid fields",
+			QueryParams: "fields=lyrics",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid filter",
+			QueryParams: "filter=group=Muse",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			exportSongsHandler := handler.ExportSongs(&mock.SongRepo{ExportedSongs: tc.Songs})
+
+			request := httptest.NewRequest("GET", "/api/v1/songs/export?"+tc.QueryParams, nil)
+
+			rr := httptest.NewRecorder()
+
+			exportSongsHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tc.ContentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, tc.Body, rr.Body.String())
+		})
+	}
+}
+
+func TestExportSongsJSON(t *testing.T) {
+	repo := &mock.SongRepo{ExportedSongs: []*dto.SongWithDetails{{ID: 1, Title: "Uprising"}, {ID: 2, Title: "Madness"}}}
+	exportSongsHandler := handler.ExportSongs(repo)
+
+	request := httptest.NewRequest("GET", "/api/v1/songs/export?format=json", nil)
+
+	rr := httptest.NewRecorder()
+	exportSongsHandler.ServeHTTP(rr, request)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var songs []*dto.SongWithDetails
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &songs))
+	assert.Equal(t, repo.ExportedSongs, songs)
+	assert.Equal(t, "song_id group_name song_name release_date link text", repo.Aggregation["fields"])
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAlbum(t *testing.T) {
+	testCases := []struct {
+		Description string
+		AlbumID     int64
+		Code        int
+	}{
+		{
+			Description: "Album exists",
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Album doesn't exist",
+			AlbumID:     404,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid album id",
+			AlbumID:     0,
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getAlbumHandler := handler.GetAlbum(&mock.AlbumRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", "/api/v1/albums/{id}", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.AlbumID)})
+
+			rr := httptest.NewRecorder()
+
+			getAlbumHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAlbums(t *testing.T) {
+	testCases := []struct {
+		Description string
+		QueryParams string
+		Code        int
+	}{
+		{
+			Description: "Without params",
+			QueryParams: "",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid title and pagination params",
+			QueryParams: "title=*moon*&limit=5&offset=5",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid limit param",
+			QueryParams: "limit=...",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getAlbumsHandler := handler.GetAlbums(&mock.AlbumRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/albums?%s", tc.QueryParams), nil)
+
+			rr := httptest.NewRecorder()
+
+			getAlbumsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArtistSongs(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ArtistID    int64
+		QueryParams string
+		Code        int
+	}{
+		{
+			Description: "Artist exists",
+			ArtistID:    mock.ValidArtistID,
+			QueryParams: "fields=song_id+song&limit=5",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Artist doesn't exist",
+			ArtistID:    404,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid fields param",
+			ArtistID:    mock.ValidArtistID,
+			QueryParams: "fields=...",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getArtistSongsHandler := handler.GetArtistSongs(&mock.ArtistRepo{}, &mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/artists/{id}/songs?%s", tc.QueryParams), nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.ArtistID)})
+
+			rr := httptest.NewRecorder()
+
+			getArtistSongsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArtist(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ArtistID    string
+		Code        int
+	}{
+		{
+			Description: "Artist exists",
+			ArtistID:    fmt.Sprintf("%d", mock.ValidArtistID),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Artist doesn't exist",
+			ArtistID:    "404",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid artist id",
+			ArtistID:    "queen",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getArtistHandler := handler.GetArtist(&mock.ArtistRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", "/api/v1/artists/{id}", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": tc.ArtistID})
+
+			rr := httptest.NewRecorder()
+
+			getArtistHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArtists(t *testing.T) {
+	testCases := []struct {
+		Description string
+		QueryParams string
+		Code        int
+	}{
+		{
+			Description: "Without params",
+			QueryParams: "",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid name and pagination params",
+			QueryParams: "name=*floyd*&limit=5&offset=5",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid limit param",
+			QueryParams: "limit=0",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid offset param",
+			QueryParams: "offset=-1",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getArtistsHandler := handler.GetArtists(&mock.ArtistRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/artists?%s", tc.QueryParams), nil)
+
+			rr := httptest.NewRecorder()
+
+			getArtistsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnrichmentJob(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		Code        int
+	}{
+		{
+			Description: "Job exists",
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Job doesn't exist",
+			SongID:      404,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid song id",
+			SongID:      -1,
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getEnrichmentJobHandler := handler.GetEnrichmentJob(&mock.EnrichmentRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/enrichment", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			getEnrichmentJobHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
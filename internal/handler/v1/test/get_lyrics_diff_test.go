@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLyricsDiff(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		Query       string
+		Code        int
+	}{
+		{
+			Description: "Valid revisions",
+			SongID:      mock.ValidSongID,
+			Query:       "?from=1&to=2",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Missing to param",
+			SongID:      mock.ValidSongID,
+			Query:       "?from=1",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid from param",
+			SongID:      mock.ValidSongID,
+			Query:       "?from=0&to=2",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Revision not found",
+			SongID:      mock.ValidSongID,
+			Query:       "?from=1&to=3",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			Query:       "?from=1&to=2",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	getLyricsDiffHandler := handler.GetLyricsDiff(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics/diff"+tc.Query, nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			getLyricsDiffHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
+
+func TestGetLyricsDiffCouplets(t *testing.T) {
+	request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics/diff?from=1&to=2", nil)
+	request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+
+	rr := httptest.NewRecorder()
+	handler.GetLyricsDiff(&mock.SongRepo{}).ServeHTTP(rr, request)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var response dto.GetLyricsDiffResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+	unified := "--- revision 1\n+++ revision 2\n@@ -1,3 +1,3 @@ couplet 2\n first couplet\n \n-second couplet\n+changed couplet\n"
+	assert.Equal(t, unified, response.Unified)
+
+	require.Len(t, response.Hunks, 1)
+	assert.Equal(t, 2, response.Hunks[0].Couplet)
+	assert.Equal(t, []string{" first couplet", " ", "-second couplet", "+changed couplet"}, response.Hunks[0].Lines)
+}
+
+func TestGetLyricsDiffPlainText(t *testing.T) {
+	request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics/diff?from=2&to=2", nil)
+	request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+	request.Header.Set("Accept", "text/x-diff")
+
+	rr := httptest.NewRecorder()
+	handler.GetLyricsDiff(&mock.SongRepo{}).ServeHTTP(rr, request)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/x-diff; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Empty(t, rr.Body.String(), "the same revisions have no diff")
+}
@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLyricsRevisions(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      string
+		Query       string
+		Code        int
+		Revisions   []int64
+	}{
+		{
+			Description: "Newest revision first",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Code:        http.StatusOK,
+			Revisions:   []int64{2, 1},
+		},
+		{
+			Description: "Page of revisions",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Query:       "?limit=1&offset=1",
+			Code:        http.StatusOK,
+			Revisions:   []int64{1},
+		},
+		{
+			Description: "Invalid limit",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Query:       "?limit=abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid song id",
+			SongID:      "abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song not found",
+			SongID:      "404",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	getLyricsRevisionsHandler := handler.GetLyricsRevisions(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics/revisions"+tc.Query, nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": tc.SongID})
+
+			rr := httptest.NewRecorder()
+
+			getLyricsRevisionsHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.GetLyricsRevisionsResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			revisions := make([]int64, 0)
+			for _, revision := range response.Revisions {
+				assert.Nil(t, revision.Text, "the list of the revisions has no texts")
+				revisions = append(revisions, revision.Revision)
+			}
+			assert.Equal(t, tc.Revisions, revisions)
+		})
+	}
+}
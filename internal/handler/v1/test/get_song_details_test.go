@@ -15,6 +15,7 @@ func TestGetSongWithDetails(t *testing.T) {
 	testCases := []struct {
 		Description string
 		QueryParams string
+		IfNoneMatch string
 		Code        int
 	}{
 		{
@@ -22,6 +23,18 @@ func TestGetSongWithDetails(t *testing.T) {
 			QueryParams: fmt.Sprintf("group=%s&song=%s", mock.ValidGroupName, mock.ValidSongName),
 			Code:        http.StatusOK,
 		},
+		{
+			Description: "Song hasn't been modified",
+			QueryParams: fmt.Sprintf("group=%s&song=%s", mock.ValidGroupName, mock.ValidSongName),
+			IfNoneMatch: fmt.Sprintf(`W/"%d"`, mock.ValidSongVersion),
+			Code:        http.StatusNotModified,
+		},
+		{
+			Description: "Song has been modified",
+			QueryParams: fmt.Sprintf("group=%s&song=%s", mock.ValidGroupName, mock.ValidSongName),
+			IfNoneMatch: fmt.Sprintf(`"%d"`, mock.ValidSongVersion-1),
+			Code:        http.StatusOK,
+		},
 		{
 			Description: "Missing the required query param group",
 			QueryParams: "group=dummy",
@@ -46,12 +59,18 @@ func TestGetSongWithDetails(t *testing.T) {
 			url := fmt.Sprintf("/api/v1/songs?%s", tc.QueryParams)
 			// fmt.Println(url)
 			request := httptest.NewRequest("GET", url, nil)
+			if tc.IfNoneMatch != "" {
+				request.Header.Set("If-None-Match", tc.IfNoneMatch)
+			}
 
 			requestRecorder := httptest.NewRecorder()
 
 			getSongDetailsHandler.ServeHTTP(requestRecorder, request)
 
 			assert.Equal(t, tc.Code, requestRecorder.Code)
+			if tc.Code == http.StatusOK || tc.Code == http.StatusNotModified {
+				assert.Equal(t, fmt.Sprintf(`"%d"`, mock.ValidSongVersion), requestRecorder.Header().Get("ETag"))
+			}
 		})
 	}
 }
@@ -0,0 +1,73 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSongDuplicates(t *testing.T) {
+	testCases := []struct {
+		Description string
+		QueryParams string
+		Match       string
+		Code        int
+	}{
+		{
+			Description: "All duplicates",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Exact duplicates",
+			QueryParams: "match=exact&limit=5&offset=5",
+			Match:       "exact",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Explicit all match",
+			QueryParams: "match=all",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid match",
+			QueryParams: "match=fuzzy",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid limit",
+			QueryParams: "limit=-1",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			repo := &mock.SongRepo{}
+			getSongDuplicatesHandler := handler.GetSongDuplicates(repo)
+
+			request := httptest.NewRequest("GET", "/api/v1/songs/duplicates?"+tc.QueryParams, nil)
+
+			rr := httptest.NewRecorder()
+
+			getSongDuplicatesHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.GetSongDuplicatesResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			require.Len(t, response.Duplicates, 1)
+			assert.Len(t, response.Duplicates[0].Songs, 2)
+			assert.Equal(t, tc.Match, repo.DuplicatesMatch)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSongLinks(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      string
+		Code        int
+	}{
+		{
+			Description: "Valid song id",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid song id",
+			SongID:      "abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song not found",
+			SongID:      "404",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	getSongLinksHandler := handler.GetSongLinks(&mock.LinkRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/links", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": tc.SongID})
+
+			rr := httptest.NewRecorder()
+
+			getSongLinksHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package handler_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/domain/mock"
 	"github.com/amicie-monami/music-library/internal/handler/v1"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetSongText(t *testing.T) {
@@ -26,12 +29,12 @@ func TestGetSongText(t *testing.T) {
 		{
 			Description: "Song exists, song lyrics not found",
 			SongID:      mock.SongIDWithoutTextData,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusNotFound,
 		},
 		{
 			Description: "Song doesn't exists",
 			SongID:      8923,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusNotFound,
 		},
 	}
 
@@ -52,3 +55,131 @@ func TestGetSongText(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSongTextSynced(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		Format      string
+		Code        int
+		ContentType string
+		Body        string
+	}{
+		{
+			Description: "Json lines",
+			SongID:      mock.ValidSongID,
+			Format:      "json",
+			Code:        http.StatusOK,
+			ContentType: "application/json",
+		},
+		{
+			Description: "Lrc file",
+			SongID:      mock.ValidSongID,
+			Format:      "lrc",
+			Code:        http.StatusOK,
+			ContentType: "text/x-lrc; charset=utf-8",
+			Body:        "[00:12.00]first line\n[00:17.20]second line\n[01:33.00]<01:33.00>third <01:33.50>line\n",
+		},
+		{
+			Description: "Song without synced lyrics",
+			SongID:      mock.SongIDWithoutTextData,
+			Format:      "lrc",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Unknown format",
+			SongID:      mock.ValidSongID,
+			Format:      "srt",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getSongTextHandler := handler.GetSongText(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics?format="+tc.Format, nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			getSongTextHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			if tc.ContentType != "" {
+				assert.Contains(t, rr.Header().Get("Content-Type"), tc.ContentType)
+			}
+			if tc.Body != "" {
+				assert.Equal(t, tc.Body, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestGetSongTextTranslation(t *testing.T) {
+	translated := "first translated couplet"
+
+	testCases := []struct {
+		Description string
+		Query       string
+		Code        int
+		Couplets    []string
+		Translation []*string
+	}{
+		{
+			Description: "Aligned translation",
+			Query:       "?lang=en-us",
+			Code:        http.StatusOK,
+			Couplets:    []string{"first couplet", "second couplet"},
+			Translation: []*string{&translated, nil},
+		},
+		{
+			Description: "Page of both sides",
+			Query:       "?lang=en-US&limit=1&offset=1",
+			Code:        http.StatusOK,
+			Couplets:    []string{"second couplet"},
+			Translation: []*string{nil},
+		},
+		{
+			Description: "Translation not found",
+			Query:       "?lang=de",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid language",
+			Query:       "?lang=english!",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Language with format",
+			Query:       "?lang=en-US&format=lrc",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getSongTextHandler := handler.GetSongText(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics"+tc.Query, nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+
+			rr := httptest.NewRecorder()
+
+			getSongTextHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.GetSongTextResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+			assert.Equal(t, mock.TranslationLang, response.Lang)
+			assert.Equal(t, tc.Couplets, response.Couplets)
+			assert.Equal(t, tc.Translation, response.Translation)
+		})
+	}
+}
@@ -19,7 +19,102 @@ func TestGetSongs(t *testing.T) {
 	}{
 		{
 			Description: "Valid filter param",
-			QueryParams: "filter=release_date=01.02.2022-08.02.2024,groups=ping+pong",
+			QueryParams: "filter=release_date=ge=01.02.2022;release_date=le=2024-02-08;group=in=(ping,pong)",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid album filter param",
+			QueryParams: "filter=album=in=(1,4)",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid tags filter param",
+			QueryParams: "filter=tags=all=(rock,%22hard+rock%22)",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid fuzzy filter param",
+			QueryParams: "filter=(group~=metalica,group~=%22guns+n+roses%22);song=fuzzy=sandman",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid nested filter param with negation",
+			QueryParams: "filter=!(link=null=true,text=like=*love*);song_id>12",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid filter param with encoded semicolon",
+			QueryParams: "filter=artist_id==3%3Brelease_date=null=false",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Unsupported filter operator",
+			QueryParams: "filter=text~=sandman",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unknown filter field",
+			QueryParams: "filter=groups==ping",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid filter value",
+			QueryParams: "filter=song_id=ge=one",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unbalanced filter parentheses",
+			QueryParams: "filter=(song_id==1,song_id==2",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Valid sort param",
+			QueryParams: "sort=-release_date:nulls_first,group,song",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid sort param with similarity",
+			QueryParams: "filter=group~=metalica&sort=-similarity,song",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Similarity sort without fuzzy filter",
+			QueryParams: "sort=-similarity",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unknown sort field",
+			QueryParams: "sort=text",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Duplicate sort field",
+			QueryParams: "sort=group,-group",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid nulls order",
+			QueryParams: "sort=release_date:nulls_middle",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Valid cursor param",
+			QueryParams: "cursor=eyJzIjoic29uZ19pZCIsInYiOlsxMl19&total=approx",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Cursor with offset",
+			QueryParams: "cursor=eyJzIjoic29uZ19pZCIsInYiOlsxMl19&offset=10",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid total param",
+			QueryParams: "total=some",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Valid fields param with tags",
+			QueryParams: "fields=song_id+song+tags",
 			Code:        http.StatusOK,
 		},
 		{
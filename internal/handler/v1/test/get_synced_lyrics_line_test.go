@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSyncedLyricsLine(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		Time        string
+		Code        int
+		Line        int
+		Next        int
+	}{
+		{
+			Description: "Before the first line",
+			SongID:      mock.ValidSongID,
+			Time:        "1.5",
+			Code:        http.StatusOK,
+			Next:        1,
+		},
+		{
+			Description: "Start of the line",
+			SongID:      mock.ValidSongID,
+			Time:        "17.2",
+			Code:        http.StatusOK,
+			Line:        2,
+			Next:        3,
+		},
+		{
+			Description: "Last line",
+			SongID:      mock.ValidSongID,
+			Time:        "93.4",
+			Code:        http.StatusOK,
+			Line:        3,
+		},
+		{
+			Description: "Missing time",
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Negative time",
+			SongID:      mock.ValidSongID,
+			Time:        "-1",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song without synced lyrics",
+			SongID:      mock.SongIDWithoutTextData,
+			Time:        "1",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			Time:        "1",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	getSyncedLyricsLineHandler := handler.GetSyncedLyricsLine(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/songs/{id}/lyrics/line?t="+tc.Time, nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			getSyncedLyricsLineHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.GetSyncedLyricsLineResponse
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+
+			lineNumber := func(line *dto.SyncedLyricsLine) int {
+				if line == nil {
+					return 0
+				}
+				return line.Line
+			}
+			assert.Equal(t, tc.Line, lineNumber(response.Line))
+			assert.Equal(t, tc.Next, lineNumber(response.Next))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTags(t *testing.T) {
+	testCases := []struct {
+		Description string
+		QueryParams string
+		Code        int
+	}{
+		{
+			Description: "Without params",
+			QueryParams: "",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid kind param",
+			QueryParams: "kind=genre&limit=20",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Invalid kind param",
+			QueryParams: "kind=mood",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	getTagsHandler := handler.GetTags(&mock.TagRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+
+			request := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/tags?%s", tc.QueryParams), nil)
+
+			rr := httptest.NewRecorder()
+
+			getTagsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
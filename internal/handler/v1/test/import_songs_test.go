@@ -0,0 +1,143 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSongs(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ContentType string
+		Query       string
+		Body        string
+		Code        int
+		Statuses    []string
+	}{
+		{
+			Description: "CSV file",
+			ContentType: "text/csv; charset=utf-8",
+			Body: "group,song,release_date,link,text\n" +
+				"Muse,Uprising,07.09.2009,open.spotify.com/track/1,\"They will not force us\n\nThey will stop degrading us\"\n" +
+				"Muse,Existing,,,\n" +
+				"Muse,Uprising,,,\n" +
+				",Hysteria,,,\n" +
+				"Muse,Madness,2012/08/20,,\n" +
+				"Muse,Resistance\n",
+			Code:     http.StatusOK,
+			Statuses: []string{"created", "skipped", "skipped", "failed", "failed", "failed"},
+		},
+		{
+			Description: "JSON array",
+			ContentType: "application/json",
+			Query:       "dry_run=true",
+			Body:        `[{"group": "Muse", "song": "Uprising", "release_date": "2009-09-07"}, {"group": "Muse", "song": 1}, {"group": "Muse", "song": "Madness", "link": "ftp://music.apple.com"}]`,
+			Code:        http.StatusOK,
+			Statuses:    []string{"created", "failed", "failed"},
+		},
+		{
+			Description: "Broken JSON array",
+			ContentType: "application/json",
+			Body:        `[{"group": "Muse", "song": "Uprising"}, {"group": "Muse", "song": `,
+			Code:        http.StatusOK,
+			Statuses:    []string{"created", "failed"},
+		},
+		{
+			Description: "NDJSON stream",
+			ContentType: "application/x-ndjson",
+			Body:        "{\"group\": \"Muse\", \"song\": \"Uprising\"}\n\n{\"group\": \"Muse\", \"lyrics\": \"-\"}\n{broken\n{\"group\": \"Muse\", \"song\": \"Madness\"}",
+			Code:        http.StatusOK,
+			Statuses:    []string{"created", "failed", "failed", "created"},
+		},
+		{
+			Description: "CSV without required column",
+			ContentType: "text/csv",
+			Body:        "group,title\nMuse,Uprising\n",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "JSON object instead of array",
+			ContentType: "application/json",
+			Body:        `{"group": "Muse", "song": "Uprising"}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unsupported content type",
+			ContentType: "application/xml",
+			Body:        "<songs/>",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid dry_run param",
+			ContentType: "application/json",
+			Query:       "dry_run=maybe",
+			Body:        "[]",
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			importSongsHandler := handler.ImportSongs(&mock.TxManager{}, &mock.SongRepo{})
+
+			request := httptest.NewRequest("POST", "/api/v1/songs/import?"+tc.Query, strings.NewReader(tc.Body))
+			request.Header.Set("Content-Type", tc.ContentType)
+
+			rr := httptest.NewRecorder()
+
+			importSongsHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code, rr.Body.String())
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var report dto.ImportSongsResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+
+			statuses := make([]string, 0, len(report.Rows))
+			for _, row := range report.Rows {
+				statuses = append(statuses, row.Status)
+			}
+			assert.Equal(t, tc.Statuses, statuses)
+			assert.Equal(t, len(tc.Statuses), report.Created+report.Skipped+report.Failed)
+		})
+	}
+}
+
+func TestImportSongsBatches(t *testing.T) {
+	var body strings.Builder
+	body.WriteString("group,song\n")
+	for idx := 0; idx < 250; idx++ {
+		fmt.Fprintf(&body, "Muse,Song%d\n", idx)
+	}
+
+	repo := &mock.SongRepo{}
+	txManager := &mock.TxManager{}
+	importSongsHandler := handler.ImportSongs(txManager, repo)
+
+	request := httptest.NewRequest("POST", "/api/v1/songs/import", strings.NewReader(body.String()))
+	request.Header.Set("Content-Type", "text/csv")
+
+	rr := httptest.NewRecorder()
+	importSongsHandler.ServeHTTP(rr, request)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, []int{100, 100, 50}, repo.ImportedBatches)
+	assert.Equal(t, 3, txManager.Calls)
+
+	var report dto.ImportSongsResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+	assert.Equal(t, 250, report.Created)
+	assert.Equal(t, 250, report.Rows[len(report.Rows)-1].Row)
+}
@@ -0,0 +1,81 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertCouplet(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		ReqBody     string
+		Code        int
+		Text        string
+	}{
+		{
+			Description: "Append couplet",
+			SongID:      mock.ValidSongID,
+			ReqBody:     `{"text": "third couplet"}`,
+			Code:        http.StatusCreated,
+			Text:        "first couplet\n\nsecond couplet\n\nthird couplet",
+		},
+		{
+			Description: "Insert at position",
+			SongID:      mock.ValidSongID,
+			ReqBody:     `{"text": "intro", "position": 0}`,
+			Code:        http.StatusCreated,
+			Text:        "intro\n\nfirst couplet\n\nsecond couplet",
+		},
+		{
+			Description: "Position out of range",
+			SongID:      mock.ValidSongID,
+			ReqBody:     `{"text": "outro", "position": 3}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Negative position",
+			SongID:      mock.ValidSongID,
+			ReqBody:     `{"text": "outro", "position": -1}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid body",
+			SongID:      mock.ValidSongID,
+			ReqBody:     `{"text": 1}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			ReqBody:     `{"text": "outro"}`,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/songs/{id}/lyrics/couplets", strings.NewReader(tc.ReqBody))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			repo := &mock.SongRepo{}
+			rr := httptest.NewRecorder()
+
+			handler.InsertCouplet(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			if tc.Text != "" {
+				assert.Equal(t, tc.Text, *repo.UpdatedDetails.Text)
+			}
+		})
+	}
+}
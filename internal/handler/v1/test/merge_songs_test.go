@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSongs(t *testing.T) {
+	tooManyDuplicates := make([]int64, 101)
+	for idx := range tooManyDuplicates {
+		tooManyDuplicates[idx] = int64(100 + idx)
+	}
+
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		SongID      int64
+		Code        int
+	}{
+		{
+			Description: "Valid duplicates",
+			ReqBody:     map[string]any{"duplicates": []int64{mock.LegacyDuplicateSongID, 14}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Duplicates field is missing",
+			ReqBody:     map[string]any{},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song is merged into itself",
+			ReqBody:     map[string]any{"duplicates": []int64{mock.ValidSongID}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Repeated duplicate",
+			ReqBody:     map[string]any{"duplicates": []int64{14, 14}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid duplicate id",
+			ReqBody:     map[string]any{"duplicates": []int64{-1}},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Too many duplicates",
+			ReqBody:     map[string]any{"duplicates": tooManyDuplicates},
+			SongID:      mock.ValidSongID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song not found",
+			ReqBody:     map[string]any{"duplicates": []int64{14}},
+			SongID:      404,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Legacy duplicate survives",
+			ReqBody:     map[string]any{"duplicates": []int64{14}},
+			SongID:      mock.LegacyDuplicateSongID,
+			Code:        http.StatusConflict,
+		},
+	}
+
+	mergeSongsHandler := handler.MergeSongs(&mock.TxManager{}, &mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("POST", "/api/v1/songs/{id}/merge", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+
+			rr := httptest.NewRecorder()
+
+			mergeSongsHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code)
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			var response dto.MergeSongsResponse
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+			assert.Equal(t, mock.ValidSongID, response.Song.ID)
+			assert.Equal(t, []int64{mock.LegacyDuplicateSongID, 14}, response.Merged)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreLyricsRevision(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      string
+		Revision    string
+		IfMatch     string
+		Code        int
+	}{
+		{
+			Description: "Valid revision",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Revision:    "1",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Matching etag",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Revision:    "1",
+			IfMatch:     httpkit.ETag(mock.ValidSongVersion),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Stale etag",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Revision:    "1",
+			IfMatch:     httpkit.ETag(mock.ValidSongVersion - 1),
+			Code:        http.StatusPreconditionFailed,
+		},
+		{
+			Description: "Invalid revision",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Revision:    "abc",
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Revision not found",
+			SongID:      fmt.Sprintf("%d", mock.ValidSongID),
+			Revision:    "3",
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Song not found",
+			SongID:      "404",
+			Revision:    "1",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/songs/{id}/lyrics/revisions/{rev}/restore", nil)
+
+			request = mux.SetURLVars(request, map[string]string{"id": tc.SongID, "rev": tc.Revision})
+
+			if tc.IfMatch != "" {
+				request.Header.Set("If-Match", tc.IfMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handler.RestoreLyricsRevision(&mock.TxManager{}, &mock.SongRepo{}).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
+
+func TestRestoreLyricsRevisionAuthor(t *testing.T) {
+	request := httptest.NewRequest("POST", "/api/v1/songs/{id}/lyrics/revisions/{rev}/restore", nil)
+	request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID), "rev": "1"})
+	request.Header.Set("X-Author", "editor")
+
+	repo := &mock.SongRepo{}
+	rr := httptest.NewRecorder()
+	handler.RestoreLyricsRevision(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "editor", repo.ChangeAuthor)
+	assert.Equal(t, "restored revision 1", repo.ChangeReason)
+	require.NotNil(t, repo.UpdatedDetails)
+	assert.Equal(t, "first couplet\n\nsecond couplet", *repo.UpdatedDetails.Text)
+	assert.Equal(t, httpkit.ETag(mock.ValidSongVersion), rr.Header().Get("ETag"))
+}
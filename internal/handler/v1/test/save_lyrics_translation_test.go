@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveLyricsTranslation(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		Lang        string
+		ReqBody     string
+		Code        int
+		SavedLang   string
+	}{
+		{
+			Description: "New translation",
+			SongID:      mock.ValidSongID,
+			Lang:        "pt-br",
+			ReqBody:     `{"text": "primeiro verso"}`,
+			Code:        http.StatusCreated,
+			SavedLang:   "pt-BR",
+		},
+		{
+			Description: "Existing translation",
+			SongID:      mock.ValidSongID,
+			Lang:        "en-US",
+			ReqBody:     `{"text": "first couplet"}`,
+			Code:        http.StatusOK,
+			SavedLang:   "en-US",
+		},
+		{
+			Description: "Invalid language",
+			SongID:      mock.ValidSongID,
+			Lang:        "english!",
+			ReqBody:     `{"text": "text"}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Too long language",
+			SongID:      mock.ValidSongID,
+			Lang:        "en-x-abcdefgh-abcdefgh-abcdefgh-abcdefgh",
+			ReqBody:     `{"text": "text"}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Empty text",
+			SongID:      mock.ValidSongID,
+			Lang:        "en",
+			ReqBody:     `{"text": "  "}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid body",
+			SongID:      mock.ValidSongID,
+			Lang:        "en",
+			ReqBody:     `{"text":`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			Lang:        "en",
+			ReqBody:     `{"text": "text"}`,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("PUT", "/api/v1/songs/{id}/translations/{lang}", strings.NewReader(tc.ReqBody))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID), "lang": tc.Lang})
+
+			repo := &mock.SongRepo{}
+			rr := httptest.NewRecorder()
+
+			handler.SaveLyricsTranslation(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			if tc.SavedLang != "" {
+				assert.Equal(t, tc.SavedLang, repo.SavedTranslation.Lang)
+				assert.Equal(t, "anonymous", repo.SavedTranslation.Author)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchLyrics(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Params      url.Values
+		Code        int
+		Lang        string
+	}{
+		{
+			Description: "Default language",
+			Params:      url.Values{"q": {"red door"}},
+			Code:        http.StatusOK,
+			Lang:        "russian",
+		},
+		{
+			Description: "English language",
+			Params:      url.Values{"q": {`"red door" -black`}, "lang": {"english"}, "limit": {"5"}},
+			Code:        http.StatusOK,
+			Lang:        "english",
+		},
+		{
+			Description: "Missing query",
+			Params:      url.Values{"q": {"  "}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Too long query",
+			Params:      url.Values{"q": {strings.Repeat("a", 257)}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unsupported language",
+			Params:      url.Values{"q": {"door"}, "lang": {"german"}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid limit",
+			Params:      url.Values{"q": {"door"}, "limit": {"abc"}},
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			repo := &mock.SearchRepo{}
+
+			request := httptest.NewRequest("GET", "/api/v1/search?"+tc.Params.Encode(), nil)
+
+			rr := httptest.NewRecorder()
+
+			handler.SearchLyrics(repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			assert.Equal(t, tc.Lang, repo.Lang)
+		})
+	}
+}
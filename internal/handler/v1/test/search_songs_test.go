@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/filter"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchSongs(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     string
+		Code        int
+	}{
+		{
+			Description: "Valid nested filter",
+			ReqBody: `{"filter": {"and": [
+				{"field": "group", "op": "in", "value": ["ping", "pong"]},
+				{"or": [{"field": "release_date", "op": "ge", "value": "2022-02-01"}, {"not": {"field": "text", "op": "null", "value": true}}]}
+			]}, "sort": ["-release_date:nulls_first", "song"], "fields": ["song_id", "group"], "limit": 20, "total": "approx"}`,
+			Code: http.StatusOK,
+		},
+		{
+			Description: "Valid fuzzy filter with similarity sort",
+			ReqBody:     `{"filter": {"field": "song", "op": "fuzzy", "value": "sandman"}, "sort": ["-similarity"]}`,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty request",
+			ReqBody:     `{}`,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Similarity sort without fuzzy filter",
+			ReqBody:     `{"filter": {"field": "song_id", "op": "gt", "value": 12}, "sort": ["similarity"]}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Unknown filter operator",
+			ReqBody:     `{"filter": {"and": [{"field": "group", "op": "contains", "value": "ping"}]}}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Unknown filter field",
+			ReqBody:     `{"filter": {"field": "groups", "op": "eq", "value": "ping"}}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid filter node",
+			ReqBody:     `{"filter": {"and": [], "or": []}}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Unknown field",
+			ReqBody:     `{"fields": ["lyrics"]}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Cursor with offset",
+			ReqBody:     `{"cursor": "eyJzIjoic29uZ19pZCIsInYiOlsxMl19", "offset": 10}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid limit",
+			ReqBody:     `{"limit": -1}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid json",
+			ReqBody:     `{"filter": `,
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	searchSongsHandler := handler.SearchSongs(&mock.SongRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "/api/v1/songs/search", bytes.NewBufferString(tc.ReqBody))
+
+			rr := httptest.NewRecorder()
+
+			searchSongsHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
+
+func TestSearchSongsFilter(t *testing.T) {
+	repo := &mock.SongRepo{}
+	searchSongsHandler := handler.SearchSongs(repo)
+
+	body := `{"filter": {"or": [{"field": "album", "op": "in", "value": [1, 4]}, {"field": "tags", "op": "all", "value": ["rock", "hard rock"]}]}}`
+	request := httptest.NewRequest("POST", "/api/v1/songs/search", bytes.NewBufferString(body))
+
+	rr := httptest.NewRecorder()
+	searchSongsHandler.ServeHTTP(rr, request)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	expected := &filter.Or{Nodes: []filter.Node{
+		&filter.Comparison{Field: "album", Op: filter.OpIn, Args: []string{"1", "4"}, Values: []any{int64(1), int64(4)}},
+		&filter.Comparison{Field: "tags", Op: filter.OpAll, Args: []string{"rock", "hard rock"}, Values: []any{"rock", "hard rock"}},
+	}}
+	assert.Equal(t, expected, repo.Aggregation["filter"])
+	assert.Equal(t, int64(10), repo.Aggregation["limit"])
+}
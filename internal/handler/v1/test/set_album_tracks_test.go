@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAlbumTracks(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		AlbumID     int64
+		Code        int
+	}{
+		{
+			Description: "Valid track list",
+			ReqBody:     map[string]any{"songs": []int64{mock.ValidSongID}},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty track list",
+			ReqBody:     map[string]any{"songs": []int64{}},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Songs field is missing",
+			ReqBody:     map[string]any{},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Repeated song",
+			ReqBody:     map[string]any{"songs": []int64{mock.ValidSongID, mock.ValidSongID}},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Song not found",
+			ReqBody:     map[string]any{"songs": []int64{404}},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Album not found",
+			ReqBody:     map[string]any{"songs": []int64{mock.ValidSongID}},
+			AlbumID:     404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	setAlbumTracksHandler := handler.SetAlbumTracks(&mock.TxManager{}, &mock.AlbumRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("PUT", "/api/v1/albums/{id}/tracks", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.AlbumID)})
+
+			rr := httptest.NewRecorder()
+
+			setAlbumTracksHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
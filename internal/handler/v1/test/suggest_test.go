@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/amicie-monami/music-library/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Params      url.Values
+		Code        int
+	}{
+		{
+			Description: "Group suggestions",
+			Params:      url.Values{"prefix": {"que"}},
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Song suggestions",
+			Params:      url.Values{"prefix": {"bohem"}, "kind": {"song"}, "limit": {"10"}},
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Missing prefix",
+			Params:      url.Values{"kind": {"song"}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Too long prefix",
+			Params:      url.Values{"prefix": {strings.Repeat("a", 65)}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Invalid kind",
+			Params:      url.Values{"prefix": {"que"}, "kind": {"album"}},
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Limit out of range",
+			Params:      url.Values{"prefix": {"que"}, "limit": {"21"}},
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	suggestHandler := handler.Suggest(&mock.SearchRepo{}, cache.New[string, []*dto.Suggestion](time.Minute, 100))
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/api/v1/suggest?"+tc.Params.Encode(), nil)
+
+			rr := httptest.NewRecorder()
+
+			suggestHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
+
+func TestSuggestCache(t *testing.T) {
+	repo := &mock.SearchRepo{}
+	suggestHandler := handler.Suggest(repo, cache.New[string, []*dto.Suggestion](time.Minute, 100))
+
+	//the prefixes which differ in case share the cached suggestions
+	for _, prefix := range []string{"Que", "que", "QUE"} {
+		request := httptest.NewRequest("GET", "/api/v1/suggest?prefix="+prefix, nil)
+
+		rr := httptest.NewRecorder()
+
+		suggestHandler.ServeHTTP(rr, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	assert.Equal(t, 1, repo.SuggestCalls)
+}
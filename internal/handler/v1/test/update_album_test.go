@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateAlbum(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		AlbumID     int64
+		Code        int
+	}{
+		{
+			Description: "Valid request body",
+			ReqBody:     map[string]any{"cover_link": "https://covers.com/2"},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty request body",
+			ReqBody:     map[string]any{},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Invalid release date",
+			ReqBody:     map[string]any{"release_date": "1973"},
+			AlbumID:     mock.ValidAlbumID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Album not found",
+			ReqBody:     map[string]any{"title": "Meddle"},
+			AlbumID:     404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	updateAlbumHandler := handler.UpdateAlbum(&mock.AlbumRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("PATCH", "/api/v1/albums/{id}", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.AlbumID)})
+
+			rr := httptest.NewRecorder()
+
+			updateAlbumHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
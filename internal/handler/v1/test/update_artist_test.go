@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateArtist(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		ArtistID    int64
+		Code        int
+	}{
+		{
+			Description: "Valid request body",
+			ReqBody:     map[string]any{"name": "The Beatles"},
+			ArtistID:    mock.ValidArtistID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty request body",
+			ReqBody:     map[string]any{},
+			ArtistID:    mock.ValidArtistID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Name is taken",
+			ReqBody:     map[string]any{"name": mock.ExistingArtistName},
+			ArtistID:    mock.ValidArtistID,
+			Code:        http.StatusConflict,
+		},
+		{
+			Description: "Artist not found",
+			ReqBody:     map[string]any{"name": "The Beatles"},
+			ArtistID:    404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	updateArtistHandler := handler.UpdateArtist(&mock.TxManager{}, &mock.ArtistRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("PATCH", "/api/v1/artists/{id}", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.ArtistID)})
+
+			rr := httptest.NewRecorder()
+
+			updateArtistHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateCouplet(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		N           string
+		ReqBody     string
+		IfMatch     string
+		Code        int
+		Text        string
+	}{
+		{
+			Description: "Second couplet",
+			SongID:      mock.ValidSongID,
+			N:           "1",
+			ReqBody:     `{"text": "\n\nnew line\nother line\n"}`,
+			Code:        http.StatusOK,
+			Text:        "first couplet\n\nnew line\nother line",
+		},
+		{
+			Description: "Couplet not found",
+			SongID:      mock.ValidSongID,
+			N:           "2",
+			ReqBody:     `{"text": "new couplet"}`,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Invalid index",
+			SongID:      mock.ValidSongID,
+			N:           "-1",
+			ReqBody:     `{"text": "new couplet"}`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Couplet with empty line",
+			SongID:      mock.ValidSongID,
+			N:           "0",
+			ReqBody:     `{"text": "one\n\ntwo"}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Empty couplet",
+			SongID:      mock.ValidSongID,
+			N:           "0",
+			ReqBody:     `{"text": " \n "}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Stale etag",
+			SongID:      mock.ValidSongID,
+			N:           "0",
+			ReqBody:     `{"text": "new couplet"}`,
+			IfMatch:     httpkit.ETag(mock.ValidSongVersion - 1),
+			Code:        http.StatusPreconditionFailed,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			N:           "0",
+			ReqBody:     `{"text": "new couplet"}`,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("PUT", "/api/v1/songs/{id}/lyrics/couplets/{n}", strings.NewReader(tc.ReqBody))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID), "n": tc.N})
+			if tc.IfMatch != "" {
+				request.Header.Set("If-Match", tc.IfMatch)
+			}
+
+			repo := &mock.SongRepo{}
+			rr := httptest.NewRecorder()
+
+			handler.UpdateCouplet(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			if tc.Text != "" {
+				assert.Equal(t, tc.Text, *repo.UpdatedDetails.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSongLink(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ReqBody     any
+		LinkID      int64
+		Code        int
+	}{
+		{
+			Description: "Make the link primary",
+			ReqBody:     map[string]any{"primary": true},
+			LinkID:      mock.ValidLinkID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Change the url",
+			ReqBody:     map[string]any{"url": "music.yandex.ru/track/12"},
+			LinkID:      mock.ValidLinkID,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Empty request body",
+			ReqBody:     map[string]any{"primary": false},
+			LinkID:      mock.ValidLinkID,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Link not found",
+			ReqBody:     map[string]any{"primary": true},
+			LinkID:      404,
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	updateSongLinkHandler := handler.UpdateSongLink(&mock.TxManager{}, &mock.LinkRepo{})
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			body, _ := json.Marshal(tc.ReqBody)
+
+			request := httptest.NewRequest("PATCH", "/api/v1/songs/{id}/links/{link_id}", bytes.NewBuffer(body))
+
+			request = mux.SetURLVars(request, map[string]string{
+				"id":      fmt.Sprintf("%d", mock.ValidSongID),
+				"link_id": fmt.Sprintf("%d", tc.LinkID),
+			})
+
+			rr := httptest.NewRecorder()
+
+			updateSongLinkHandler.ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/domain/model"
 	"github.com/amicie-monami/music-library/internal/handler/v1"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpdateSong(t *testing.T) {
@@ -19,6 +22,7 @@ func TestUpdateSong(t *testing.T) {
 		Description string
 		ReqBody     any
 		SongID      int64
+		IfMatch     string
 		Code        int
 	}{
 		{
@@ -31,23 +35,51 @@ func TestUpdateSong(t *testing.T) {
 			Description: "Empty request body",
 			ReqBody:     map[string]any{},
 			SongID:      mock.ValidSongID,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Invalid release date fields",
 			ReqBody:     map[string]any{"release_date": "2022.03.05"},
 			SongID:      mock.ValidSongID,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusUnprocessableEntity,
 		},
 		{
 			Description: "Song not found",
-			ReqBody:     map[string]any{"release_date": "2022.03.05"},
+			ReqBody:     map[string]any{"Song": "Song"},
 			SongID:      9090,
-			Code:        http.StatusBadRequest,
+			Code:        http.StatusNotFound,
+		},
+		{
+			Description: "Current etag",
+			ReqBody:     map[string]any{"Song": "Song"},
+			SongID:      mock.ValidSongID,
+			IfMatch:     fmt.Sprintf(`"%d"`, mock.ValidSongVersion),
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Any etag",
+			ReqBody:     map[string]any{"Song": "Song"},
+			SongID:      mock.ValidSongID,
+			IfMatch:     "*",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Stale etag",
+			ReqBody:     map[string]any{"Song": "Song"},
+			SongID:      mock.ValidSongID,
+			IfMatch:     fmt.Sprintf(`"%d"`, mock.ValidSongVersion-1),
+			Code:        http.StatusPreconditionFailed,
+		},
+		{
+			Description: "Weak etag",
+			ReqBody:     map[string]any{"Song": "Song"},
+			SongID:      mock.ValidSongID,
+			IfMatch:     fmt.Sprintf(`W/"%d"`, mock.ValidSongVersion),
+			Code:        http.StatusPreconditionFailed,
 		},
 	}
 
-	addSongHandler := handler.UpdateSong(&mock.SongRepo{})
+	addSongHandler := handler.UpdateSong(&mock.TxManager{}, &mock.SongRepo{})
 
 	for _, tc := range testCases {
 		t.Run(tc.Description, func(t *testing.T) {
@@ -56,12 +88,195 @@ func TestUpdateSong(t *testing.T) {
 			request := httptest.NewRequest("GET", "/api/songs/{id}", bytes.NewBuffer(body))
 
 			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+			if tc.IfMatch != "" {
+				request.Header.Set("If-Match", tc.IfMatch)
+			}
 
 			rr := httptest.NewRecorder()
 
 			addSongHandler.ServeHTTP(rr, request)
 
 			assert.Equal(t, tc.Code, rr.Code)
+			if tc.Code == http.StatusOK {
+				assert.Equal(t, fmt.Sprintf(`"%d"`, mock.ValidSongVersion), rr.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestUpdateSongPatch(t *testing.T) {
+	testCases := []struct {
+		Description string
+		ContentType string
+		ReqBody     string
+		Code        int
+		Song        *model.Song
+		Details     *model.SongDetail
+	}{
+		{
+			Description: "Plain json leaves the empty fields",
+			ContentType: "application/json",
+			ReqBody:     `{"group": "Muse"}`,
+			Code:        http.StatusOK,
+			Song:        &model.Song{ID: mock.ValidSongID, Group: "Muse"},
+		},
+		{
+			Description: "Merge patch clears the fields",
+			ContentType: "application/merge-patch+json",
+			ReqBody:     `{"release_date": null, "link": null}`,
+			Code:        http.StatusOK,
+			Details:     &model.SongDetail{SongID: mock.ValidSongID, Clear: []string{model.SongDetailReleaseDate, model.SongDetailLink}},
+		},
+		{
+			Description: "Merge patch sets the fields",
+			ContentType: "application/merge-patch+json; charset=utf-8",
+			ReqBody:     `{"song": "Uprising", "text": "new text", "group": "Group12"}`,
+			Code:        http.StatusOK,
+			Song:        &model.Song{ID: mock.ValidSongID, Name: "Uprising"},
+			Details:     &model.SongDetail{SongID: mock.ValidSongID, Text: ptr("new text")},
+		},
+		{
+			Description: "Merge patch can't clear the song name",
+			ContentType: "application/merge-patch+json",
+			ReqBody:     `{"song": null}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Merge patch with unknown field",
+			ContentType: "application/merge-patch+json",
+			ReqBody:     `{"lyrics": "text"}`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Malformed merge patch",
+			ContentType: "application/merge-patch+json",
+			ReqBody:     `["text"]`,
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Json patch replaces the couplet",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "test", "path": "/couplets/1", "value": "second couplet"}, {"op": "replace", "path": "/couplets/1", "value": "new couplet"}]`,
+			Code:        http.StatusOK,
+			Details:     &model.SongDetail{SongID: mock.ValidSongID, Text: ptr("first couplet\n\nnew couplet")},
+		},
+		{
+			Description: "Json patch removes the text",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "remove", "path": "/text"}]`,
+			Code:        http.StatusOK,
+			Details:     &model.SongDetail{SongID: mock.ValidSongID, Clear: []string{model.SongDetailText}},
+		},
+		{
+			Description: "Json patch without changes",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "test", "path": "/song", "value": "Song12"}]`,
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Json patch with failed test",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "test", "path": "/song", "value": "Song13"}, {"op": "remove", "path": "/link"}]`,
+			Code:        http.StatusConflict,
+		},
+		{
+			Description: "Json patch with missing path",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "replace", "path": "/couplets/2", "value": "third couplet"}]`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Json patch changes text and couplets differently",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "replace", "path": "/text", "value": "text"}, {"op": "add", "path": "/couplets/-", "value": "third couplet"}]`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Json patch with invalid release date",
+			ContentType: "application/json-patch+json",
+			ReqBody:     `[{"op": "replace", "path": "/release_date", "value": "2022.03.05"}]`,
+			Code:        http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			repo := &mock.SongRepo{}
+			updateSongHandler := handler.UpdateSong(&mock.TxManager{}, repo)
+
+			request := httptest.NewRequest("PATCH", "/api/v1/songs/{id}", strings.NewReader(tc.ReqBody))
+			request.Header.Set("Content-Type", tc.ContentType)
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+
+			rr := httptest.NewRecorder()
+
+			updateSongHandler.ServeHTTP(rr, request)
+
+			require.Equal(t, tc.Code, rr.Code, rr.Body.String())
+			if tc.Code != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tc.Song, repo.UpdatedSong)
+			assert.Equal(t, tc.Details, repo.UpdatedDetails)
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestUpdateSongChangeAuthor(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Author      string
+		Reason      string
+		Code        int
+		SetAuthor   string
+	}{
+		{
+			Description: "Author and reason",
+			Author:      "editor",
+			Reason:      "typo",
+			Code:        http.StatusOK,
+			SetAuthor:   "editor",
+		},
+		{
+			Description: "Anonymous author",
+			Code:        http.StatusOK,
+			SetAuthor:   "anonymous",
+		},
+		{
+			Description: "Too long author",
+			Author:      strings.Repeat("a", 129),
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Too long reason",
+			Reason:      strings.Repeat("a", 513),
+			Code:        http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("PATCH", "/api/v1/songs/{id}", strings.NewReader(`{"text": "new text"}`))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+			request.Header.Set("X-Author", tc.Author)
+			request.Header.Set("X-Change-Reason", tc.Reason)
+
+			repo := &mock.SongRepo{}
+			rr := httptest.NewRecorder()
+
+			handler.UpdateSong(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+			assert.Equal(t, tc.SetAuthor, repo.ChangeAuthor)
+			if tc.Code == http.StatusOK {
+				assert.Equal(t, tc.Reason, repo.ChangeReason)
+			}
 		})
 	}
 }
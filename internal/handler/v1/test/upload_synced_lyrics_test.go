@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/mock"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/handler/v1"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadSyncedLyrics(t *testing.T) {
+	testCases := []struct {
+		Description string
+		SongID      int64
+		ReqBody     string
+		IfMatch     string
+		Code        int
+	}{
+		{
+			Description: "Valid lrc",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[ti:Song]\n[00:12.00]first line\n[00:17.20]second line",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Valid enhanced lrc",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[00:12.00]<00:12.00>first <00:12.40>line",
+			Code:        http.StatusOK,
+		},
+		{
+			Description: "Line without time",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[00:12.00]first line\nsecond line",
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "No text",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[ti:Song]\n[00:12.00]",
+			Code:        http.StatusUnprocessableEntity,
+		},
+		{
+			Description: "Too large file",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[00:12.00]" + strings.Repeat("a", 1<<20),
+			Code:        http.StatusBadRequest,
+		},
+		{
+			Description: "Stale etag",
+			SongID:      mock.ValidSongID,
+			ReqBody:     "[00:12.00]first line",
+			IfMatch:     httpkit.ETag(mock.ValidSongVersion - 1),
+			Code:        http.StatusPreconditionFailed,
+		},
+		{
+			Description: "Song not found",
+			SongID:      404,
+			ReqBody:     "[00:12.00]first line",
+			Code:        http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("PUT", "/api/v1/songs/{id}/lyrics", strings.NewReader(tc.ReqBody))
+
+			request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", tc.SongID)})
+			request.Header.Set("Content-Type", "text/x-lrc")
+			if tc.IfMatch != "" {
+				request.Header.Set("If-Match", tc.IfMatch)
+			}
+
+			rr := httptest.NewRecorder()
+
+			handler.UploadSyncedLyrics(&mock.TxManager{}, &mock.SongRepo{}).ServeHTTP(rr, request)
+
+			assert.Equal(t, tc.Code, rr.Code)
+		})
+	}
+}
+
+func TestUploadSyncedLyricsText(t *testing.T) {
+	request := httptest.NewRequest("PUT", "/api/v1/songs/{id}/lyrics", strings.NewReader("[00:30.00]chorus\n[00:10.00][00:40.00]\n[00:05.00]verse"))
+	request = mux.SetURLVars(request, map[string]string{"id": fmt.Sprintf("%d", mock.ValidSongID)})
+
+	repo := &mock.SongRepo{}
+	rr := httptest.NewRecorder()
+	handler.UploadSyncedLyrics(&mock.TxManager{}, repo).ServeHTTP(rr, request)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	//the lines are ordered by their time, the instrumental breaks separate the couplets
+	expected := []*model.SyncedLyricsLine{
+		{StartMs: 5000, Text: "verse"},
+		{StartMs: 10000, Text: ""},
+		{StartMs: 30000, Text: "chorus"},
+		{StartMs: 40000, Text: ""},
+	}
+	assert.Equal(t, expected, repo.SyncedLines)
+
+	require.NotNil(t, repo.UpdatedDetails)
+	assert.Equal(t, "verse\n\nchorus\n", *repo.UpdatedDetails.Text)
+	assert.Equal(t, httpkit.ETag(mock.ValidSongVersion), rr.Header().Get("ETag"))
+}
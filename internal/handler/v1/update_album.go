@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type albumUpdater interface {
+	Update(ctx context.Context, album *model.Album) error
+}
+
+// @Summary Изменение данных альбома
+// @Description Метод позволяет изменить название, дату релиза и ссылку на обложку альбома.
+// @Router /albums/{id} [patch]
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор альбома."
+// @Param album body dto.UpdateAlbumRequest true "Данные альбома, которые необходимо изменить."
+// @Success 200 {string} string "Данные были успешно обновлены, нет возвращаемого значения."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Альбом не найден."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func UpdateAlbum(repo albumUpdater) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		albumID, err := parsePathVarAlbumID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		album, err := parseUpdateAlbumBody(albumID, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		if err := repo.Update(r.Context(), album); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("album has been successfully updated", "id", albumID)
+		httpkit.Ok(w, nil)
+	})
+}
+
+func parseUpdateAlbumBody(albumID int64, r *http.Request) (*model.Album, error) {
+	var data dto.UpdateAlbumRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse album data", "parseUpdateAlbumBody", err.Error(), nil)
+	}
+
+	if data.Title == "" && data.ReleaseDate == "" && data.CoverLink == "" {
+		return nil, dto.NewValidationError("missing the data for updates", "parseUpdateAlbumBody")
+	}
+
+	return newAlbumModel(albumID, data.Title, data.ReleaseDate, data.CoverLink)
+}
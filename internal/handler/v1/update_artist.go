@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type artistRenamer interface {
+	Rename(ctx context.Context, artist *model.Artist) error
+}
+
+// @Summary Переименование исполнителя
+// @Description Метод изменяет название исполнителя, новое название получают все песни исполнителя.
+// @Router /artists/{id} [patch]
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор исполнителя."
+// @Param artist body dto.UpdateArtistRequest true "Новое название исполнителя."
+// @Success 200 {string} string "Исполнитель переименован, нет данных в теле ответа."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Исполнитель не найден."
+// @Failure 409 {object} dto.Error "Название занято другим исполнителем."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func UpdateArtist(txManager transactor, repo artistRenamer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		artistID, err := parsePathVarArtistID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		artist, err := parseUpdateArtistBody(artistID, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		tx := func(ctx context.Context) error {
+			return repo.Rename(ctx, artist)
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("artist has been renamed", "id", artist.ID, "name", artist.Name)
+		httpkit.Ok(w, nil)
+	})
+}
+
+func parseUpdateArtistBody(artistID int64, r *http.Request) (*model.Artist, error) {
+	var data dto.UpdateArtistRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse artist data", "parseUpdateArtistBody", err.Error(), nil)
+	}
+
+	if data.Name == "" {
+		return nil, dto.NewValidationError("missing the data for updates", "parseUpdateArtistBody", dto.FieldError{Field: "name", Message: "field name is required"})
+	}
+
+	return &model.Artist{ID: artistID, Name: data.Name}, nil
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/gorilla/mux"
+)
+
+type coupletsEditor interface {
+	LockSongVersion(ctx context.Context, id int64) (int64, error)
+	SetChangeAuthor(ctx context.Context, author string, reason string) error
+	GetSongText(ctx context.Context, id int64) (*string, error)
+	UpdateSongDetails(ctx context.Context, details *model.SongDetail) error
+}
+
+// @Summary Изменение куплета песни
+// @Description Метод заменяет один куплет текста песни. Куплеты - части текста, разделённые пустыми строками, так же, как в /songs/{id}/lyrics. Текст читается и изменяется в одной транзакции под блокировкой песни, поэтому одновременные изменения разных куплетов не затирают друг друга.
+// @Router /songs/{id}/lyrics/couplets/{n} [put]
+// @Tags Songs
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param n path int true "Номер куплета, начиная с 0 (как offset в /songs/{id}/lyrics)."
+// @Param couplet body dto.UpdateCoupletRequest true "Новый текст куплета, без пустых строк."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412."
+// @Param X-Author header string false "Автор изменения (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения (до 512 символов)."
+// @Success 200 {object} dto.GetSongTextResponse "Все куплеты песни после изменения. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректный номер куплета, тело запроса или заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня или куплет не найдены."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 422 {object} dto.Error "Пустой текст куплета или текст с пустыми строками."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func UpdateCouplet(txManager transactor, repo coupletsEditor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		n, err := parsePathVarCoupletIndex(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var requestBody dto.UpdateCoupletRequest
+		if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+			sendError(w, r, dto.NewError(400, "failed to parse couplet", "UpdateCouplet", err.Error(), nil))
+			return
+		}
+
+		couplet, err := normalizeCouplet(requestBody.Text)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		couplets, version, err := editCouplets(r, txManager, repo, songID, func(couplets []string) ([]string, error) {
+			if err := checkCoupletIndex(couplets, n); err != nil {
+				return nil, err
+			}
+
+			couplets[n] = couplet
+			return couplets, nil
+		})
+
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song couplet has been updated", "song_id", songID, "n", n, "version", version)
+		w.Header().Set("ETag", httpkit.ETag(version))
+		httpkit.Ok(w, dto.GetSongTextResponse{SongID: songID, Couplets: couplets})
+	})
+}
+
+// editCouplets applies the edit to the couplets of the lyrics and returns all couplets after the edit and the new
+// version of the song. The song stays locked from the reading of the lyrics until the commit, so the concurrent
+// edits are applied one after another to the latest lyrics
+func editCouplets(r *http.Request, txManager transactor, repo coupletsEditor, songID int64, edit func(couplets []string) ([]string, error)) ([]string, int64, error) {
+	author, reason, err := parseChangeAuthor(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		version  int64
+		couplets []string
+	)
+
+	tx := func(ctx context.Context) error {
+		current, err := repo.LockSongVersion(ctx, songID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkIfMatch(r, current); err != nil {
+			return err
+		}
+
+		text, err := repo.GetSongText(ctx, songID)
+		if err != nil {
+			return err
+		}
+
+		couplets = make([]string, 0)
+		if text != nil && *text != "" {
+			couplets = splitCouplets(*text)
+		}
+
+		if couplets, err = edit(couplets); err != nil {
+			return err
+		}
+
+		if err := repo.SetChangeAuthor(ctx, author, reason); err != nil {
+			return err
+		}
+
+		//the lyrics without the couplets are cleared
+		songDetails := &model.SongDetail{SongID: songID, Clear: []string{model.SongDetailText}}
+		if len(couplets) != 0 {
+			text := strings.Join(couplets, "\n\n")
+			songDetails = &model.SongDetail{SongID: songID, Text: &text}
+		}
+
+		if err := repo.UpdateSongDetails(ctx, songDetails); err != nil {
+			return err
+		}
+
+		version, err = repo.LockSongVersion(ctx, songID)
+		return err
+	}
+
+	if err := txManager.Do(r.Context(), tx); err != nil {
+		return nil, 0, err
+	}
+
+	return couplets, version, nil
+}
+
+// parsePathVarCoupletIndex parses the index of the couplet starting from 0
+func parsePathVarCoupletIndex(r *http.Request) (int, error) {
+	n, err := strconv.Atoi(mux.Vars(r)["n"])
+	if err != nil || n < 0 {
+		details := fmt.Sprintf("n=%s, but must be a couplet index >= 0", mux.Vars(r)["n"])
+		return 0, dto.NewError(400, "invalid couplet index in url", "parsePathVarCoupletIndex", details, nil)
+	}
+	return n, nil
+}
+
+func checkCoupletIndex(couplets []string, n int) error {
+	if n >= len(couplets) {
+		details := fmt.Sprintf("n=%d, but the song has %d couplets", n, len(couplets))
+		return dto.NewNotFoundError("couplet not found", "checkCoupletIndex", details)
+	}
+	return nil
+}
+
+// normalizeCouplet trims the empty lines around the couplet, the couplet can't contain the empty lines
+// because they separate the couplets
+func normalizeCouplet(text string) (string, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	start, end := 0, len(lines)
+	for start < end && strings.TrimSpace(lines[start]) == "" {
+		start++
+	}
+	for end > start && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+
+	if start == end {
+		field := dto.FieldError{Field: "text", Message: "text must be a non-empty string"}
+		return "", dto.NewValidationError("invalid couplet", "normalizeCouplet", field)
+	}
+
+	for _, line := range lines[start:end] {
+		if strings.TrimSpace(line) == "" {
+			field := dto.FieldError{Field: "text", Message: "couplet can't contain empty lines, they separate the couplets"}
+			return "", dto.NewValidationError("invalid couplet", "normalizeCouplet", field)
+		}
+	}
+
+	return strings.Join(lines[start:end], "\n"), nil
+}
@@ -5,95 +5,242 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"mime"
 	"net/http"
-	"time"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/domain/model"
 	"github.com/amicie-monami/music-library/pkg/httpkit"
 )
 
+// transactor executes the fn in the transaction carried by the context passed to the fn
+type transactor interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type songDataUpdater interface {
-	Tx(ctx context.Context, txActions func() error) error
+	LockSongVersion(ctx context.Context, id int64) (int64, error)
+	SetChangeAuthor(ctx context.Context, author string, reason string) error
+	GetSong(ctx context.Context, id int64) (*dto.SongWithDetails, error)
 	UpdateSong(ctx context.Context, song *model.Song) error
 	UpdateSongDetails(ctx context.Context, details *model.SongDetail) error
 }
 
+// content types of the patches of the song
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// limits of the headers of the author of the change
+const (
+	maxChangeAuthorLength = 128
+	maxChangeReasonLength = 512
+)
+
+// songUpdate is the update of the song: either the song and its details sent as the plain json,
+// or the patch of the song document which is applied to the current song
+type songUpdate struct {
+	song    *model.Song
+	details *model.SongDetail
+	patch   func(doc any) (any, error)
+}
+
 // @Summary Изменение данных песни
-// @Description Метод позволяет изменить данные песни, хранящиеся в библиотеке.
+// @Description Метод позволяет изменить данные песни, хранящиеся в библиотеке. Формат тела определяется заголовком Content-Type.
+// @Description application/json: переданные непустые поля заменяют данные песни, пустые и отсутствующие поля не изменяются.
+// @Description application/merge-patch+json (RFC 7396): отсутствующие поля не изменяются, null очищает release_date, link или text. Пример: {"link": null, "text": "..."}.
+// @Description application/json-patch+json (RFC 6902): массив операций add, remove, replace, move, copy, test над документом песни {group, song, release_date, link, text, couplets}, где couplets - куплеты текста. Пример замены второго куплета: [{"op": "replace", "path": "/couplets/1", "value": "..."}]. Если операция test не выполнена, возвращается 409.
 // @Router /songs/{id} [patch]
 // @Tags Songs
 // @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
 // @Produce json
 // @Param id path int true "Идентификатор песни, данные которой необходимо изменить."
 // @Param songInfo body dto.UpdateSongRequest true "Данные песни, которые необходимо изменить."
-// @Success 200 {string} string "Данные были успешно обновлены, нет возвращаемого значения."
-// @Failure 400 {object} dto.Error "Неверный запрос, некорректные значения параметров."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412. Без заголовка песня изменяется безусловно."
+// @Param X-Author header string false "Автор изменения, сохраняется в ревизии текста песни (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения, сохраняется в ревизии текста песни (до 512 символов)."
+// @Success 200 {string} string "Данные были успешно обновлены, нет возвращаемого значения. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса или заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 409 {object} dto.Error "У исполнителя уже есть песня с таким названием (без учёта регистра и лишних пробелов) или не выполнена операция test."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса или операций патча, описание ошибок в поле fields."
 // @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
-func UpdateSong(repo songDataUpdater) http.Handler {
+func UpdateSong(txManager transactor, repo songDataUpdater) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Accept-Patch", mergePatchContentType+", "+jsonPatchContentType)
+
 		songID, err := parsePathVarSongID(r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
+			return
+		}
+
+		author, reason, err := parseChangeAuthor(r)
+		if err != nil {
+			sendError(w, r, err)
 			return
 		}
 
 		//parse body
-		song, songDetails, err := parseUpdateSongBody(songID, r)
+		update, err := parseUpdateSongRequest(songID, r)
 		if err != nil {
-			sendError(w, err)
+			sendError(w, r, err)
 			return
 		}
 
-		//transaction actions
-		tx := func() error {
-			if song != nil && repo.UpdateSong(r.Context(), song) != nil {
+		//transaction actions, the song stays locked from the version check until the commit
+		var version int64
+		tx := func(ctx context.Context) error {
+			current, err := repo.LockSongVersion(ctx, songID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkIfMatch(r, current); err != nil {
 				return err
 			}
 
-			if songDetails != nil && repo.UpdateSongDetails(r.Context(), songDetails) != nil {
+			//the revision of the lyrics is recorded by the database with the author of the transaction
+			if err := repo.SetChangeAuthor(ctx, author, reason); err != nil {
 				return err
 			}
-			return nil
+
+			song, songDetails := update.song, update.details
+			if update.patch != nil {
+				if song, songDetails, err = applySongPatch(ctx, repo, songID, update.patch); err != nil {
+					return err
+				}
+			}
+
+			if song != nil {
+				if err := repo.UpdateSong(ctx, song); err != nil {
+					return err
+				}
+			}
+
+			if songDetails != nil {
+				if err := repo.UpdateSongDetails(ctx, songDetails); err != nil {
+					return err
+				}
+			}
+
+			version, err = repo.LockSongVersion(ctx, songID)
+			return err
 		}
 
-		if err := repo.Tx(r.Context(), tx); err != nil {
-			sendError(w, err)
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
 			return
 		}
 
-		slog.Info("song has been successfully updated", "id", songID)
+		slog.Info("song has been successfully updated", "id", songID, "version", version)
+		w.Header().Set("ETag", httpkit.ETag(version))
 		httpkit.Ok(w, nil)
 	})
 }
 
+// checkIfMatch checks the If-Match header against the current version of the song,
+// the request without the header isn't conditional
+func checkIfMatch(r *http.Request, version int64) error {
+	header := r.Header.Get("If-Match")
+	if header == "" || httpkit.MatchETag(header, httpkit.ETag(version), false) {
+		return nil
+	}
+
+	details := fmt.Sprintf("If-Match=%s, but the current etag is %s", header, httpkit.ETag(version))
+	return dto.NewPreconditionFailedError("song has been changed", "checkIfMatch", details)
+}
+
+// parseChangeAuthor parses the author and the reason of the change from the X-Author and X-Change-Reason headers,
+// the change without the author is made by the anonymous author
+func parseChangeAuthor(r *http.Request) (string, string, error) {
+	author := strings.TrimSpace(r.Header.Get("X-Author"))
+	if author == "" {
+		author = "anonymous"
+	}
+
+	if utf8.RuneCountInString(author) > maxChangeAuthorLength {
+		details := fmt.Sprintf("X-Author must be up to %d characters", maxChangeAuthorLength)
+		return "", "", dto.NewError(400, "invalid X-Author header", "parseChangeAuthor", details, nil)
+	}
+
+	reason := strings.TrimSpace(r.Header.Get("X-Change-Reason"))
+	if utf8.RuneCountInString(reason) > maxChangeReasonLength {
+		details := fmt.Sprintf("X-Change-Reason must be up to %d characters", maxChangeReasonLength)
+		return "", "", dto.NewError(400, "invalid X-Change-Reason header", "parseChangeAuthor", details, nil)
+	}
+
+	return author, reason, nil
+}
+
+// parseUpdateSongRequest parses the body of the request by its content type, the unknown content types
+// are parsed as the plain json for the compatibility with the clients which don't send the header
+func parseUpdateSongRequest(songID int64, r *http.Request) (*songUpdate, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		mediaType = ""
+	}
+
+	switch mediaType {
+	case mergePatchContentType:
+		return parseMergePatchBody(r)
+	case jsonPatchContentType:
+		return parseJSONPatchBody(r)
+	default:
+		song, songDetails, err := parseUpdateSongBody(songID, r)
+		if err != nil {
+			return nil, err
+		}
+		return &songUpdate{song: song, details: songDetails}, nil
+	}
+}
+
 func parseUpdateSongBody(songID int64, r *http.Request) (*model.Song, *model.SongDetail, error) {
-	var (
-		requestBody dto.UpdateSongRequest
-		song        *model.Song
-		songDetails *model.SongDetail
-	)
+	var requestBody dto.UpdateSongRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
-		return nil, nil, err
+		return nil, nil, dto.NewError(400, "failed to parse song data", "parseUpdateSongBody", err.Error(), nil)
 	}
 
 	if requestBody.Group == "" && requestBody.Song == "" && requestBody.ReleaseDate == "" && requestBody.Link == "" && requestBody.Text == "" {
-		return nil, nil, dto.NewError(400, "missing the data for updates", "parseUpdateSongBody", nil, nil)
+		return nil, nil, dto.NewValidationError("missing the data for updates", "parseUpdateSongBody")
 	}
 
-	song = &model.Song{ID: songID, Group: requestBody.Group, Name: requestBody.Song}
+	//the empty fields of the plain json aren't updated
+	var song *model.Song
+	if requestBody.Group != "" || requestBody.Song != "" {
+		song = &model.Song{ID: songID, Group: requestBody.Group, Name: requestBody.Song}
+	}
 
-	songDetails = &model.SongDetail{SongID: songID, Text: &requestBody.Text, Link: &requestBody.Link}
+	if requestBody.ReleaseDate == "" && requestBody.Link == "" && requestBody.Text == "" {
+		return song, nil, nil
+	}
+
+	songDetails := &model.SongDetail{SongID: songID}
+	if requestBody.Text != "" {
+		songDetails.Text = &requestBody.Text
+	}
+
+	if requestBody.Link != "" {
+		link, fieldErr := normalizeLinkURL("link", requestBody.Link)
+		if fieldErr != nil {
+			return nil, nil, dto.NewValidationError("incorrect link url", "parseUpdateSongBody", *fieldErr)
+		}
+		songDetails.Link = &link
+	}
 
 	//if release_date has been sent
 	if requestBody.ReleaseDate != "" {
-
-		//try to parse release_date
-		releaseDate, err := time.Parse("01.01.2006", requestBody.ReleaseDate)
+		releaseDate, err := parseReleaseDate(requestBody.ReleaseDate)
 		if err != nil {
-			details := fmt.Sprintf("release_date=%s", requestBody.ReleaseDate)
-			return nil, nil, &dto.Error{Code: 400, Message: "failed to parse release_date field", Details: details}
+			field := dto.FieldError{Field: "release_date", Message: err.Error()}
+			return nil, nil, dto.NewValidationError("failed to parse release_date field", "parseUpdateSongBody", field)
 		}
 
 		songDetails.ReleaseDate = &releaseDate
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+)
+
+type songLinkUpdater interface {
+	UpdateLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error)
+}
+
+// @Summary Изменение ссылки песни
+// @Description Метод позволяет изменить адрес ссылки (платформа определяется заново) или сделать ссылку основной.
+// @Router /songs/{id}/links/{link_id} [patch]
+// @Tags Links
+// @Accept json
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param link_id path int true "Идентификатор ссылки."
+// @Param link body dto.UpdateSongLinkRequest true "Данные ссылки, которые необходимо изменить."
+// @Success 200 {object} dto.GetSongLinksResponse "Все ссылки песни после изменения."
+// @Failure 400 {object} dto.Error "Неверный запрос, некорректное тело запроса."
+// @Failure 404 {object} dto.Error "Ссылка не найдена."
+// @Failure 409 {object} dto.Error "Ссылка уже добавлена."
+// @Failure 422 {object} dto.Error "Некорректные значения полей запроса, описание ошибок в поле fields."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func UpdateSongLink(txManager transactor, repo songLinkUpdater) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		linkID, err := parsePathVarLinkID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		link, err := parseUpdateSongLinkBody(songID, linkID, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		var links []*dto.SongLink
+		tx := func(ctx context.Context) error {
+			links, err = repo.UpdateLink(ctx, link)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("song link has been updated", "song_id", songID, "link_id", linkID)
+		httpkit.Ok(w, dto.GetSongLinksResponse{SongID: songID, Links: links})
+	})
+}
+
+func parseUpdateSongLinkBody(songID int64, linkID int64, r *http.Request) (*model.SongLink, error) {
+	var data dto.UpdateSongLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		return nil, dto.NewError(400, "failed to parse link data", "parseUpdateSongLinkBody", err.Error(), nil)
+	}
+
+	//the primary flag can only be set, the song always keeps one of its links primary
+	if data.URL == "" && !data.Primary {
+		return nil, dto.NewValidationError("missing the data for updates", "parseUpdateSongLinkBody")
+	}
+
+	link := &model.SongLink{ID: linkID, SongID: songID, Primary: data.Primary}
+
+	if data.URL != "" {
+		url, fieldErr := normalizeLinkURL("url", data.URL)
+		if fieldErr != nil {
+			return nil, dto.NewValidationError("incorrect link url", "parseUpdateSongLinkBody", *fieldErr)
+		}
+		link.URL, link.Platform = url, model.DetectPlatform(url)
+	}
+
+	return link, nil
+}
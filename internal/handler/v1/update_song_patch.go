@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/jsonpatch"
+)
+
+type songGetter interface {
+	GetSong(ctx context.Context, id int64) (*dto.SongWithDetails, error)
+}
+
+// songDocumentFields are the fields of the song document, the target of the patches
+var songDocumentFields = []string{"group", "song", "release_date", "link", "text", "couplets"}
+
+// parseMergePatchBody parses the JSON Merge Patch (RFC 7396) of the song, the null fields clear the details
+func parseMergePatchBody(r *http.Request) (*songUpdate, error) {
+	var patch map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		return nil, dto.NewError(400, "failed to parse merge patch", "parseMergePatchBody", err.Error(), nil)
+	}
+
+	if len(patch) == 0 {
+		return nil, dto.NewValidationError("missing the data for updates", "parseMergePatchBody")
+	}
+
+	fields := make([]dto.FieldError, 0)
+	for key := range patch {
+		if !slices.Contains(songDocumentFields, key) {
+			fields = append(fields, dto.FieldError{Field: key, Message: fmt.Sprintf("unknown field, must be one of %v", songDocumentFields)})
+		}
+	}
+
+	if len(fields) != 0 {
+		return nil, dto.NewValidationError("invalid merge patch", "parseMergePatchBody", fields...)
+	}
+
+	return &songUpdate{patch: func(doc any) (any, error) {
+		return jsonpatch.Merge(doc, patch)
+	}}, nil
+}
+
+// parseJSONPatchBody parses the JSON Patch (RFC 6902) of the song. The errors of the operations are
+// found when the patch is applied, the failed test operation means the song has another state
+func parseJSONPatchBody(r *http.Request) (*songUpdate, error) {
+	var ops []jsonpatch.Operation
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		return nil, dto.NewError(400, "failed to parse json patch", "parseJSONPatchBody", err.Error(), nil)
+	}
+
+	if len(ops) == 0 {
+		return nil, dto.NewValidationError("missing the data for updates", "parseJSONPatchBody")
+	}
+
+	return &songUpdate{patch: func(doc any) (any, error) {
+		patched, err := jsonpatch.Apply(doc, ops)
+		if err == nil {
+			return patched, nil
+		}
+
+		patchErr, ok := err.(*jsonpatch.Error)
+		if !ok {
+			return nil, dto.NewError(500, "internal server error", "parseJSONPatchBody", nil, err.Error())
+		}
+
+		if patchErr.TestFailed {
+			details := fmt.Sprintf("operation %d: %s", patchErr.Index, patchErr.Message)
+			return nil, dto.NewConflictError("test operation has failed", "parseJSONPatchBody", details)
+		}
+
+		field := dto.FieldError{Field: fmt.Sprintf("[%d].%s", patchErr.Index, patchErr.Field), Message: patchErr.Message}
+		return nil, dto.NewValidationError("failed to apply json patch", "parseJSONPatchBody", field)
+	}}, nil
+}
+
+// applySongPatch applies the patch to the document of the current song and returns the changes
+// of the song and its details, nil if nothing has changed
+func applySongPatch(ctx context.Context, repo songGetter, songID int64, patch func(doc any) (any, error)) (*model.Song, *model.SongDetail, error) {
+	current, err := repo.GetSong(ctx, songID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc := songDocument(current)
+	patched, err := patch(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return songDocumentChanges(songID, doc, patched)
+}
+
+// songDocument returns the song as the document of the patches. The missing details are null,
+// the couplets are the couplets of the text, the song without the text has no couplets
+func songDocument(song *dto.SongWithDetails) map[string]any {
+	nullable := func(value *string) any {
+		if value == nil {
+			return nil
+		}
+		return *value
+	}
+
+	couplets := make([]any, 0)
+	if song.Text != nil && *song.Text != "" {
+		for _, couplet := range splitCouplets(*song.Text) {
+			couplets = append(couplets, couplet)
+		}
+	}
+
+	return map[string]any{
+		"group":        song.Group,
+		"song":         song.Title,
+		"release_date": nullable(song.ReleaseDate),
+		"link":         nullable(song.Link),
+		"text":         nullable(song.Text),
+		"couplets":     couplets,
+	}
+}
+
+// songDocumentChanges compares the patched document with the original one. The removed fields are the same as null,
+// the changed couplets replace the text. The song or the details are nil if they haven't changed
+func songDocumentChanges(songID int64, original map[string]any, patched any) (*model.Song, *model.SongDetail, error) {
+	doc, ok := patched.(map[string]any)
+	if !ok {
+		return nil, nil, dto.NewValidationError("song must be an object", "songDocumentChanges")
+	}
+
+	var (
+		song    *model.Song
+		details *model.SongDetail
+		fields  = make([]dto.FieldError, 0)
+	)
+
+	invalid := func(field string, message string) {
+		fields = append(fields, dto.FieldError{Field: field, Message: message})
+	}
+
+	changed := func(field string) bool {
+		return !reflect.DeepEqual(original[field], doc[field])
+	}
+
+	for key := range doc {
+		if !slices.Contains(songDocumentFields, key) {
+			invalid(key, fmt.Sprintf("unknown field, must be one of %v", songDocumentFields))
+		}
+	}
+
+	//the names of the song can't be cleared
+	for _, field := range []string{"group", "song"} {
+		if !changed(field) {
+			continue
+		}
+
+		value, ok := doc[field].(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			invalid(field, fmt.Sprintf("%s must be a non-empty string", field))
+			continue
+		}
+
+		if song == nil {
+			song = &model.Song{ID: songID}
+		}
+
+		if field == "group" {
+			song.Group = value
+		} else {
+			song.Name = value
+		}
+	}
+
+	detailsChanges := func() *model.SongDetail {
+		if details == nil {
+			details = &model.SongDetail{SongID: songID}
+		}
+		return details
+	}
+
+	if changed("release_date") {
+		switch value := doc["release_date"].(type) {
+		case nil:
+			detailsChanges().Clear = append(detailsChanges().Clear, model.SongDetailReleaseDate)
+		case string:
+			releaseDate, err := parseReleaseDate(value)
+			if err != nil {
+				invalid("release_date", err.Error())
+				break
+			}
+			detailsChanges().ReleaseDate = &releaseDate
+		default:
+			invalid("release_date", "release_date must be a date string or null")
+		}
+	}
+
+	if changed("link") {
+		switch value := doc["link"].(type) {
+		case nil:
+			detailsChanges().Clear = append(detailsChanges().Clear, model.SongDetailLink)
+		case string:
+			link, fieldErr := normalizeLinkURL("link", value)
+			if fieldErr != nil {
+				invalid("link", fieldErr.Message)
+				break
+			}
+			detailsChanges().Link = &link
+		default:
+			invalid("link", "link must be a url string or null")
+		}
+	}
+
+	text, textChanged := doc["text"], changed("text")
+	if changed("couplets") {
+		coupletsText, err := joinCouplets(doc["couplets"])
+		if err != nil {
+			invalid("couplets", err.Error())
+		} else if textChanged && !reflect.DeepEqual(text, coupletsText) {
+			invalid("couplets", "text and couplets are changed differently, only one of them can be changed")
+		}
+		text, textChanged = coupletsText, true
+	}
+
+	if textChanged {
+		switch value := text.(type) {
+		case nil:
+			detailsChanges().Clear = append(detailsChanges().Clear, model.SongDetailText)
+		case string:
+			detailsChanges().Text = &value
+		default:
+			invalid("text", "text must be a string or null")
+		}
+	}
+
+	if len(fields) != 0 {
+		return nil, nil, dto.NewValidationError("invalid song", "songDocumentChanges", fields...)
+	}
+
+	return song, details, nil
+}
+
+// joinCouplets joins the couplets into the text of the song, no couplets are no text
+func joinCouplets(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	couplets, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("couplets must be an array of strings")
+	}
+
+	if len(couplets) == 0 {
+		return nil, nil
+	}
+
+	lines := make([]string, 0, len(couplets))
+	for idx, couplet := range couplets {
+		line, ok := couplet.(string)
+		if !ok {
+			return nil, fmt.Errorf("couplet %d must be a string", idx)
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n\n"), nil
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/amicie-monami/music-library/pkg/lrc"
+)
+
+// maxLyricsFileSize is the limit of the size of the uploaded lyrics file
+const maxLyricsFileSize = 1 << 20
+
+type syncedLyricsUploader interface {
+	LockSongVersion(ctx context.Context, id int64) (int64, error)
+	SetChangeAuthor(ctx context.Context, author string, reason string) error
+	SetSyncedLyrics(ctx context.Context, songID int64, lines []*model.SyncedLyricsLine) error
+	UpdateSongDetails(ctx context.Context, details *model.SongDetail) error
+	GetSyncedLyrics(ctx context.Context, songID int64) ([]*dto.SyncedLyricsLine, error)
+}
+
+// @Summary Загрузка синхронизированного текста песни
+// @Description Метод заменяет текст песни текстом файла в формате LRC или enhanced LRC и сохраняет время начала каждой строки и слова. Строка с несколькими метками времени повторяется в каждый из моментов, тег [offset:] сдвигает все метки, остальные теги метаданных игнорируются. Строка без текста означает проигрыш.
+// @Description Текст песни становится строками файла в порядке их времени, изменение текста записывается в историю ревизий. Если позже текст песни будет изменён, синхронизация удаляется.
+// @Router /songs/{id}/lyrics [put]
+// @Tags Songs
+// @Accept text/x-lrc
+// @Produce json
+// @Param id path int true "Идентификатор песни."
+// @Param lyrics body string true "Текст в формате LRC, например [00:12.00]Первая строка или [00:12.00]<00:12.00>Первая <00:12.50>строка."
+// @Param If-Match header string false "ETag песни, полученный ранее. Если песня была изменена после его получения, возвращается 412."
+// @Param X-Author header string false "Автор изменения (до 128 символов). По умолчанию anonymous."
+// @Param X-Change-Reason header string false "Причина изменения (до 512 символов)."
+// @Success 200 {object} dto.GetSyncedLyricsResponse "Синхронизированный текст сохранён. Заголовок ETag содержит новую версию песни."
+// @Failure 400 {object} dto.Error "Неверный запрос, слишком большой файл или некорректные заголовки автора изменения."
+// @Failure 404 {object} dto.Error "Песня не найдена."
+// @Failure 412 {object} dto.Error "Песня была изменена, ETag из заголовка If-Match устарел."
+// @Failure 422 {object} dto.Error "Некорректный файл LRC, номер ошибочной строки в описании ошибки."
+// @Failure 500 {object} dto.Error "Внутреняя ошибка сервера."
+func UploadSyncedLyrics(txManager transactor, repo syncedLyricsUploader) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songID, err := parsePathVarSongID(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		author, reason, err := parseChangeAuthor(r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		lines, err := parseSyncedLyricsBody(w, r)
+		if err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		text := syncedLyricsText(lines)
+
+		var (
+			version int64
+			synced  []*dto.SyncedLyricsLine
+		)
+
+		tx := func(ctx context.Context) error {
+			current, err := repo.LockSongVersion(ctx, songID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkIfMatch(r, current); err != nil {
+				return err
+			}
+
+			if err := repo.SetChangeAuthor(ctx, author, reason); err != nil {
+				return err
+			}
+
+			//the timing is saved before the text, so the text matches it and the timing is kept
+			if err := repo.SetSyncedLyrics(ctx, songID, lines); err != nil {
+				return err
+			}
+
+			if err := repo.UpdateSongDetails(ctx, &model.SongDetail{SongID: songID, Text: &text}); err != nil {
+				return err
+			}
+
+			if synced, err = repo.GetSyncedLyrics(ctx, songID); err != nil {
+				return err
+			}
+
+			version, err = repo.LockSongVersion(ctx, songID)
+			return err
+		}
+
+		if err := txManager.Do(r.Context(), tx); err != nil {
+			sendError(w, r, err)
+			return
+		}
+
+		slog.Info("synced song lyrics have been uploaded", "song_id", songID, "lines", len(synced), "version", version)
+		w.Header().Set("ETag", httpkit.ETag(version))
+		httpkit.Ok(w, dto.GetSyncedLyricsResponse{SongID: songID, Lines: synced})
+	})
+}
+
+// parseSyncedLyricsBody parses the LRC file of the body, the lyrics must have at least one line with the text
+func parseSyncedLyricsBody(w http.ResponseWriter, r *http.Request) ([]*model.SyncedLyricsLine, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxLyricsFileSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, dto.NewError(400, "lyrics file is too large", "parseSyncedLyricsBody", "the limit is 1MB", nil)
+		}
+		return nil, dto.NewError(400, "failed to read lyrics file", "parseSyncedLyricsBody", err.Error(), nil)
+	}
+
+	lrcLines, err := lrc.Parse(string(body))
+	if err != nil {
+		field := dto.FieldError{Field: "lyrics", Message: err.Error()}
+		return nil, dto.NewValidationError("invalid lrc file", "parseSyncedLyricsBody", field)
+	}
+
+	lines := make([]*model.SyncedLyricsLine, 0, len(lrcLines))
+	hasText := false
+	for _, lrcLine := range lrcLines {
+		line := &model.SyncedLyricsLine{StartMs: lrcLine.Start.Milliseconds(), Text: lrcLine.Text}
+		for _, word := range lrcLine.Words {
+			line.Words = append(line.Words, model.SyncedLyricsWord{StartMs: word.Start.Milliseconds(), Text: word.Text})
+		}
+
+		hasText = hasText || lrcLine.Text != ""
+		lines = append(lines, line)
+	}
+
+	if !hasText {
+		field := dto.FieldError{Field: "lyrics", Message: "lyrics must have at least one timed line with the text"}
+		return nil, dto.NewValidationError("invalid lrc file", "parseSyncedLyricsBody", field)
+	}
+
+	return lines, nil
+}
+
+// syncedLyricsText joins the timed lines into the text of the song, the empty lines separate the couplets
+func syncedLyricsText(lines []*model.SyncedLyricsLine) string {
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+	}
+	return strings.Join(texts, "\n")
+}
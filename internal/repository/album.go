@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// Album object adapter for database operations with the albums and album_tracks tables
+type Album struct {
+	db dbContext
+}
+
+func NewAlbum(db dbContext) *Album {
+	return &Album{db}
+}
+
+/// ------------ Interface ------------ ///
+
+// GetAlbums returns the page of albums with the number of their tracks.
+// The title param is an optional ILIKE pattern, where "*" is a wildcard
+func (r *Album) GetAlbums(ctx context.Context, title string, limit int64, offset int64) ([]*dto.Album, error) {
+	slog.Debug("get albums", "title", title, "limit", limit, "offset", offset)
+
+	queryBuilder := selectAlbums().
+		OrderBy("albums.release_date NULLS LAST", "albums.id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if title != "" {
+		condition, err := buildILikeCondition("albums.title", title)
+		if err != nil {
+			return nil, err
+		}
+		queryBuilder = queryBuilder.Where(condition)
+	}
+
+	query, args := queryBuilder.MustSql()
+
+	albums := make([]*dto.Album, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &albums, query, args...); err != nil {
+		return nil, wrapQueryExecError("album.GetAlbums", err)
+	}
+
+	return albums, nil
+}
+
+// GetAlbum returns the album with the ordered track list
+func (r *Album) GetAlbum(ctx context.Context, id int64) (*dto.Album, error) {
+	slog.Debug("get album", "id", id)
+
+	query, args := selectAlbums().Where(squirrel.Eq{"albums.id": id}).MustSql()
+
+	var album dto.Album
+	if err := executor(ctx, r.db).GetContext(ctx, &album, query, args...); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("id=%d", id)
+			return nil, dto.NewNotFoundError("album not found", "album.GetAlbum", details)
+		}
+
+		return nil, wrapQueryExecError("album.GetAlbum", err)
+	}
+
+	query, args = squirrel.
+		Select("album_tracks.position", "album_tracks.song_id", "artists.name AS group_name", "songs.song_name").
+		From("album_tracks").
+		Join("songs ON songs.id = album_tracks.song_id").
+		Join("artists ON artists.id = songs.artist_id").
+		Where(squirrel.Eq{"album_tracks.album_id": id}).
+		OrderBy("album_tracks.position").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	album.Tracks = make([]*dto.AlbumTrack, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &album.Tracks, query, args...); err != nil {
+		return nil, wrapQueryExecError("album.GetAlbum", err)
+	}
+
+	return &album, nil
+}
+
+func (r *Album) Create(ctx context.Context, album *model.Album) error {
+	slog.Debug("create album", "data", fmt.Sprintf("%+v", album))
+
+	query, args := squirrel.
+		Insert("albums").
+		Columns("title", "release_date", "cover_link").
+		Values(album.Title, album.ReleaseDate, album.CoverLink).
+		Suffix("RETURNING id").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&album.ID); err != nil {
+		return wrapQueryExecError("album.Create", err)
+	}
+
+	return nil
+}
+
+func (r *Album) Update(ctx context.Context, album *model.Album) error {
+	slog.Debug("update album", "data", fmt.Sprintf("%+v", album))
+
+	setMap := map[string]any{
+		"title":        album.Title,
+		"release_date": album.ReleaseDate,
+		"cover_link":   album.CoverLink,
+	}
+
+	affectedCount, err := updateRowContext(ctx, executor(ctx, r.db), "albums", squirrel.Eq{"id": album.ID}, setMap)
+	if err != nil {
+		return wrapQueryExecError("album.Update", err)
+	}
+
+	if affectedCount == 0 {
+		details := fmt.Sprintf("id=%d", album.ID)
+		return dto.NewNotFoundError("album not found", "album.Update", details)
+	}
+
+	return nil
+}
+
+func (r *Album) Delete(ctx context.Context, id int64) error {
+	slog.Debug("delete album", "id", id)
+
+	query, args := squirrel.
+		Delete("albums").
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return wrapQueryExecError("album.Delete", err)
+	}
+
+	affectedCount, err := result.RowsAffected()
+	if err != nil {
+		return wrapQueryExecError("album.Delete", err)
+	}
+
+	if affectedCount == 0 {
+		details := fmt.Sprintf("id=%d", id)
+		return dto.NewNotFoundError("album not found", "album.Delete", details)
+	}
+
+	return nil
+}
+
+// SetTracks replaces the track list of the album, positions follow the order of songIDs
+func (r *Album) SetTracks(ctx context.Context, albumID int64, songIDs []int64) error {
+	slog.Debug("set album tracks", "album_id", albumID, "songs", songIDs)
+
+	return execTx(ctx, r.db, "album.SetTracks", func(tx dbContext) error {
+		//lock the album, so concurrent updates of the track list are applied one by one
+		var id int64
+		if err := tx.QueryRowContext(ctx, "SELECT id FROM albums WHERE id = $1 FOR UPDATE", albumID).Scan(&id); err != nil {
+
+			if err == sql.ErrNoRows {
+				details := fmt.Sprintf("id=%d", albumID)
+				return dto.NewNotFoundError("album not found", "album.SetTracks", details)
+			}
+
+			return wrapQueryExecError("album.SetTracks", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM album_tracks WHERE album_id = $1", albumID); err != nil {
+			return wrapQueryExecError("album.SetTracks", err)
+		}
+
+		query := `
+			INSERT INTO album_tracks (album_id, song_id, position)
+			SELECT $1, tracks.song_id, tracks.position
+			FROM unnest($2::BIGINT[]) WITH ORDINALITY AS tracks (song_id, position)`
+
+		if _, err := tx.ExecContext(ctx, query, albumID, songIDs); err != nil {
+
+			if isPgError(err, foreignKeyViolationCode) {
+				field := dto.FieldError{Field: "songs", Message: fmt.Sprintf("songs=%v contain the missing song", songIDs)}
+				return dto.NewValidationError("song not found", "album.SetTracks", field)
+			}
+
+			return wrapQueryExecError("album.SetTracks", err)
+		}
+
+		return nil
+	})
+}
+
+/// ------------ Helpers ------------ ///
+
+func selectAlbums() squirrel.SelectBuilder {
+	return squirrel.
+		Select(
+			"albums.id",
+			"albums.title",
+			"albums.release_date",
+			"albums.cover_link",
+			"count(album_tracks.song_id) AS tracks_count",
+		).
+		From("albums").
+		LeftJoin("album_tracks ON album_tracks.album_id = albums.id").
+		GroupBy("albums.id").
+		PlaceholderFormat(squirrel.Dollar)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// Artist object adapter for database operations with the artists table
+type Artist struct {
+	db dbContext
+}
+
+func NewArtist(db dbContext) *Artist {
+	return &Artist{db}
+}
+
+/// ------------ Interface ------------ ///
+
+// GetArtists returns the page of artists with the number of their songs.
+// The name param is an optional ILIKE pattern, where "*" is a wildcard
+func (r *Artist) GetArtists(ctx context.Context, name string, limit int64, offset int64) ([]*dto.Artist, error) {
+	slog.Debug("get artists", "name", name, "limit", limit, "offset", offset)
+
+	queryBuilder := squirrel.
+		Select("artists.id", "artists.name", "count(songs.id) AS songs_count").
+		From("artists").
+		LeftJoin("songs ON songs.artist_id = artists.id").
+		GroupBy("artists.id").
+		OrderBy("artists.name", "artists.id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if name != "" {
+		condition, err := buildILikeCondition("artists.name", name)
+		if err != nil {
+			return nil, err
+		}
+		queryBuilder = queryBuilder.Where(condition)
+	}
+
+	query, args := queryBuilder.MustSql()
+
+	artists := make([]*dto.Artist, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &artists, query, args...); err != nil {
+		return nil, wrapQueryExecError("artist.GetArtists", err)
+	}
+
+	return artists, nil
+}
+
+func (r *Artist) GetArtist(ctx context.Context, id int64) (*dto.Artist, error) {
+	slog.Debug("get artist", "id", id)
+
+	query, args := squirrel.
+		Select("artists.id", "artists.name", "count(songs.id) AS songs_count").
+		From("artists").
+		LeftJoin("songs ON songs.artist_id = artists.id").
+		Where(squirrel.Eq{"artists.id": id}).
+		GroupBy("artists.id").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	var artist dto.Artist
+	if err := executor(ctx, r.db).GetContext(ctx, &artist, query, args...); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("id=%d", id)
+			return nil, dto.NewNotFoundError("artist not found", "artist.GetArtist", details)
+		}
+
+		return nil, wrapQueryExecError("artist.GetArtist", err)
+	}
+
+	return &artist, nil
+}
+
+func (r *Artist) Create(ctx context.Context, artist *model.Artist) error {
+	slog.Debug("create artist", "data", fmt.Sprintf("%+v", artist))
+
+	query, args := squirrel.
+		Insert("artists").
+		Columns("name").
+		Values(artist.Name).
+		Suffix("RETURNING id").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&artist.ID); err != nil {
+
+		if isPgError(err, uniqueViolationCode) {
+			details := fmt.Sprintf("name=%s", artist.Name)
+			return dto.NewConflictError("artist already exists", "artist.Create", details)
+		}
+
+		return wrapQueryExecError("artist.Create", err)
+	}
+
+	return nil
+}
+
+// Rename changes the name of the artist, the songs of the artist get the new group name.
+// The versions of the songs are incremented in the same transaction, so the stale ETags of the songs are rejected
+func (r *Artist) Rename(ctx context.Context, artist *model.Artist) error {
+	slog.Debug("rename artist", "data", fmt.Sprintf("%+v", artist))
+
+	return execTx(ctx, r.db, "artist.Rename", func(tx dbContext) error {
+		affectedCount, err := updateRowContext(ctx, tx, "artists", squirrel.Eq{"id": artist.ID}, map[string]any{"name": artist.Name})
+		if err != nil {
+
+			if isPgError(err, uniqueViolationCode) {
+				details := fmt.Sprintf("name=%s", artist.Name)
+				return dto.NewConflictError("artist already exists", "artist.Rename", details)
+			}
+
+			return wrapQueryExecError("artist.Rename", err)
+		}
+
+		if affectedCount == 0 {
+			details := fmt.Sprintf("id=%d", artist.ID)
+			return dto.NewNotFoundError("artist not found", "artist.Rename", details)
+		}
+
+		if _, err := tx.ExecContext(ctx, "UPDATE songs SET version = version + 1 WHERE artist_id = $1", artist.ID); err != nil {
+			return wrapQueryExecError("artist.Rename", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the artist, artists with songs can't be deleted
+func (r *Artist) Delete(ctx context.Context, id int64) error {
+	slog.Debug("delete artist", "id", id)
+
+	query, args := squirrel.
+		Delete("artists").
+		Where(squirrel.Eq{"id": id}).
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+
+		if isPgError(err, foreignKeyViolationCode) {
+			details := fmt.Sprintf("id=%d", id)
+			return dto.NewConflictError("artist has songs, delete or move them first", "artist.Delete", details)
+		}
+
+		return wrapQueryExecError("artist.Delete", err)
+	}
+
+	affectedCount, err := result.RowsAffected()
+	if err != nil {
+		return wrapQueryExecError("artist.Delete", err)
+	}
+
+	if affectedCount == 0 {
+		details := fmt.Sprintf("id=%d", id)
+		return dto.NewNotFoundError("artist not found", "artist.Delete", details)
+	}
+
+	return nil
+}
@@ -2,13 +2,76 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// postgres error codes
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+// isPgError reports whether the err is a postgres error with the code
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+// postgres error classes and codes of the unavailable database
+const (
+	connectionExceptionClass = "08"
+	cannotConnectNowCode     = "57P03"
+	tooManyConnectionsCode   = "53300"
+)
+
+// isUnavailableError reports whether the err is caused by the unavailable database rather than by the query
+func isUnavailableError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) || pgconn.Timeout(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, connectionExceptionClass) ||
+			pgErr.Code == cannotConnectNowCode || pgErr.Code == tooManyConnectionsCode
+	}
+	return false
+}
+
+// postgres error codes of the transactions which can be retried
+const (
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// isRetryableTxError reports whether the transaction has failed on the serialization failure or the deadlock,
+// the database error wrapped by wrapQueryExecError is checked as well
+func isRetryableTxError(err error) bool {
+	var dtoErr *dto.Error
+	if errors.As(err, &dtoErr) {
+		cause, ok := dtoErr.DebugMsg.(error)
+		if !ok {
+			return false
+		}
+		err = cause
+	}
+	return isPgError(err, serializationFailureCode) || isPgError(err, deadlockDetectedCode)
+}
+
 // updateRow Updates a row in the specified table.
 // It takes
 //   - db - database connection
@@ -26,7 +89,7 @@ func updateRowContext(ctx context.Context, db dbContext, table string, pkEqCostr
 	setMapWithoutZeros := make(map[string]any)
 
 	for key, value := range setMap {
-		if value != nil && value != 0 && value != "" {
+		if !isZeroValue(value) {
 			setMapWithoutZeros[key] = value
 		}
 	}
@@ -37,7 +100,7 @@ func updateRowContext(ctx context.Context, db dbContext, table string, pkEqCostr
 
 	query, args := squirrel.
 		Update(table).
-		SetMap(setMap).
+		SetMap(setMapWithoutZeros).
 		Where(pkEqCostraint).
 		PlaceholderFormat(squirrel.Dollar).
 		MustSql()
@@ -50,46 +113,14 @@ func updateRowContext(ctx context.Context, db dbContext, table string, pkEqCostr
 	return result.RowsAffected()
 }
 
-// buildParamBasedAndConditions constructs a sql "and" condition [cond1 AND cond2 AND cond3...]
-// based on the provided filter map and condition resolvers
-//
-// This function iterates over the filter map, where each key is a parameter name, and the corresponding value is a string
-// representing the filter condition. For each key in the filter map, it finds a corresponding function in the
-// conditionResolvers map, which is responsible for constructing the sql condition for that parameter
-//
-// Parameters:
-//   - filter: A map where keys are parameter names (e.g., "song_name", "group") and values are their respective filter conditions
-//   - conditionResolvers: A map where keys match the parameter names in the filter, and values are functions (conditionBuilderFunc)
-//     that generate the SQL condition for the corresponding parameter
-//
-// Returns:
-//   - squirrel.Sqlizer: A SQL condition builder object representing the combined "and" conditions
-//   - error: An error if there was an issue constructing any condition
-//   - nil if no conditions generated
-func buildParamBasedAndConditions(params map[string]any, conditionResolvers map[string]conditionBuilderFunc) (squirrel.Sqlizer, error) {
-	var conditions squirrel.And
-
-	for param, builderFunc := range conditionResolvers {
-		paramValue, ok := params[param].(string)
-		if !ok || paramValue == "" {
-			continue
-		}
-
-		condition, err := builderFunc(paramValue)
-		if err != nil {
-			return nil, err
-		}
-
-		if condition != nil {
-			conditions = append(conditions, condition)
-		}
-	}
-
-	if len(conditions) == 0 {
-		return nil, nil
+// isZeroValue reports whether the value is nil, a nil pointer, 0 or an empty string
+func isZeroValue(value any) bool {
+	if value == nil || value == 0 || value == int64(0) || value == "" {
+		return true
 	}
 
-	return conditions, nil
+	reflectValue := reflect.ValueOf(value)
+	return reflectValue.Kind() == reflect.Pointer && reflectValue.IsNil()
 }
 
 // buildILikeCondition constructs an SQL "ILIKE" condition [col ILIKE pattern1 OR col ILIKE pattern2...]
@@ -121,30 +152,3 @@ func buildILikeCondition(columnName string, patterns string) (squirrel.Sqlizer,
 
 	return orCondition, nil
 }
-
-// parseComparison converts a comparison string into the corresponding SQL operator.
-//
-//	Supported values are:
-//	- "gt", "ge", "lt", "le", "ne", "eq"
-//
-// Returns
-//   - "" if operator is unknown
-//   - equals sql operator
-func parseComparison(value string) string {
-	switch value {
-	case "gt":
-		return ">"
-	case "ge":
-		return ">="
-	case "lt":
-		return "<"
-	case "le":
-		return "<="
-	case "ne":
-		return "!="
-	case "eq":
-		return "="
-	default:
-		return ""
-	}
-}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// Enrichment object adapter for database operations with the enrichment_jobs table
+type Enrichment struct {
+	db dbContext
+}
+
+func NewEnrichment(db dbContext) *Enrichment {
+	return &Enrichment{db}
+}
+
+/// ------------ Interface ------------ ///
+
+// EnqueueEmpty creates pending jobs for the songs which details are still empty and haven't got a job yet
+func (r *Enrichment) EnqueueEmpty(ctx context.Context) (int64, error) {
+	query := `
+		INSERT INTO enrichment_jobs (song_id)
+		SELECT songs.id FROM songs
+		JOIN song_details ON song_details.song_id = songs.id
+		WHERE song_details.release_date IS NULL
+			AND song_details.text IS NULL
+			AND NOT EXISTS (SELECT 1 FROM song_links WHERE song_links.song_id = songs.id)
+		ON CONFLICT (song_id) DO NOTHING`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query)
+	if err != nil {
+		return 0, wrapQueryExecError("enrichment.EnqueueEmpty", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// Claim marks up to limit due pending jobs as running and returns them.
+// Locked rows are skipped, so several workers can claim jobs concurrently
+func (r *Enrichment) Claim(ctx context.Context, limit int) ([]*model.EnrichmentJob, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE enrichment_jobs SET
+				status = 'running',
+				attempts = attempts + 1,
+				updated_at = now()
+			WHERE song_id IN (
+				SELECT song_id FROM enrichment_jobs
+				WHERE status = 'pending' AND next_attempt_at <= now()
+				ORDER BY next_attempt_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING song_id, attempts
+		)
+		SELECT claimed.song_id, claimed.attempts, artists.name, songs.song_name
+		FROM claimed
+		JOIN songs ON songs.id = claimed.song_id
+		JOIN artists ON artists.id = songs.artist_id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, wrapQueryExecError("enrichment.Claim", err)
+	}
+	defer rows.Close()
+
+	jobs := make([]*model.EnrichmentJob, 0)
+	for rows.Next() {
+		job := new(model.EnrichmentJob)
+		if err := rows.Scan(&job.SongID, &job.Attempts, &job.Group, &job.Song); err != nil {
+			return nil, wrapQueryExecError("enrichment.Claim", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, wrapQueryExecError("enrichment.Claim", err)
+	}
+
+	return jobs, nil
+}
+
+// Complete marks the job as done
+func (r *Enrichment) Complete(ctx context.Context, songID int64) error {
+	query := `
+		UPDATE enrichment_jobs SET
+			status = 'done',
+			last_error = NULL,
+			updated_at = now()
+		WHERE song_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, songID); err != nil {
+		return wrapQueryExecError("enrichment.Complete", err)
+	}
+	return nil
+}
+
+// Fail records the error of the attempt. The job is scheduled to the nextAttemptAt
+// or marked as failed if nextAttemptAt is nil
+func (r *Enrichment) Fail(ctx context.Context, songID int64, reason string, nextAttemptAt *time.Time) error {
+	slog.Debug("fail enrichment job", "song_id", songID, "reason", reason)
+
+	status := "failed"
+	if nextAttemptAt != nil {
+		status = "pending"
+	}
+
+	query := `
+		UPDATE enrichment_jobs SET
+			status = $2,
+			last_error = $3,
+			next_attempt_at = COALESCE($4, next_attempt_at),
+			updated_at = now()
+		WHERE song_id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, songID, status, reason, nextAttemptAt); err != nil {
+		return wrapQueryExecError("enrichment.Fail", err)
+	}
+	return nil
+}
+
+// Release returns the interrupted running job to the queue without counting the attempt
+func (r *Enrichment) Release(ctx context.Context, songID int64) error {
+	query := `
+		UPDATE enrichment_jobs SET
+			status = 'pending',
+			attempts = GREATEST(attempts - 1, 0),
+			updated_at = now()
+		WHERE song_id = $1 AND status = 'running'`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, songID); err != nil {
+		return wrapQueryExecError("enrichment.Release", err)
+	}
+	return nil
+}
+
+// ResetRunning returns the jobs which were left running by a stopped instance to the queue.
+// Only the jobs claimed longer than the lease ago are returned, the younger ones can be processed by the live instances
+func (r *Enrichment) ResetRunning(ctx context.Context, lease time.Duration) (int64, error) {
+	query := `
+		UPDATE enrichment_jobs SET status = 'pending', updated_at = now()
+		WHERE status = 'running' AND updated_at < now() - make_interval(secs => $1)`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, lease.Seconds())
+	if err != nil {
+		return 0, wrapQueryExecError("enrichment.ResetRunning", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *Enrichment) GetJob(ctx context.Context, songID int64) (*dto.EnrichmentJob, error) {
+	slog.Debug("get enrichment job", "song_id", songID)
+
+	query := `
+		SELECT song_id, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM enrichment_jobs
+		WHERE song_id = $1`
+
+	var job dto.EnrichmentJob
+	if err := executor(ctx, r.db).GetContext(ctx, &job, query, songID); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("song_id=%d", songID)
+			return nil, dto.NewNotFoundError("enrichment job not found", "enrichment.GetJob", details)
+		}
+
+		return nil, wrapQueryExecError("enrichment.GetJob", err)
+	}
+
+	return &job, nil
+}
+
+// Retry requeues the job of the song with the reset attempts counter.
+// The job is created if the song hasn't got one, running jobs are left untouched
+func (r *Enrichment) Retry(ctx context.Context, songID int64) (*dto.EnrichmentJob, error) {
+	slog.Debug("retry enrichment job", "song_id", songID)
+
+	query := `
+		INSERT INTO enrichment_jobs (song_id)
+		SELECT id FROM songs WHERE id = $1
+		ON CONFLICT (song_id) DO UPDATE SET
+			status = 'pending',
+			attempts = 0,
+			last_error = NULL,
+			next_attempt_at = now(),
+			updated_at = now()
+		WHERE enrichment_jobs.status <> 'running'
+		RETURNING song_id, status, attempts, last_error, next_attempt_at, created_at, updated_at`
+
+	var job dto.EnrichmentJob
+	if err := executor(ctx, r.db).GetContext(ctx, &job, query, songID); err != nil {
+		if err != sql.ErrNoRows {
+			return nil, wrapQueryExecError("enrichment.Retry", err)
+		}
+
+		//nothing has been inserted or updated: either the song doesn't exist or the job is running
+		existing, err := r.GetJob(ctx, songID)
+		if err != nil {
+			details := fmt.Sprintf("id=%d", songID)
+			return nil, dto.NewNotFoundError("song not found", "enrichment.Retry", details)
+		}
+
+		details := fmt.Sprintf("song_id=%d status=%s", songID, existing.Status)
+		return nil, dto.NewConflictError("enrichment job is running", "enrichment.Retry", details)
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// Link object adapter for database operations with the song_links table
+type Link struct {
+	db dbContext
+}
+
+func NewLink(db dbContext) *Link {
+	return &Link{db}
+}
+
+/// ------------ Interface ------------ ///
+
+// GetLinks returns the links of the song, the primary link goes first
+func (r *Link) GetLinks(ctx context.Context, songID int64) ([]*dto.SongLink, error) {
+	slog.Debug("get song links", "song_id", songID)
+
+	links, err := getSongLinks(ctx, executor(ctx, r.db), songID)
+	if err != nil {
+		return nil, err
+	}
+
+	//an empty list is ambiguous: the song can have no links or not exist at all
+	if len(links) == 0 {
+		var exists bool
+		if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1)", songID).Scan(&exists); err != nil {
+			return nil, wrapQueryExecError("link.GetLinks", err)
+		}
+
+		if !exists {
+			details := fmt.Sprintf("id=%d", songID)
+			return nil, dto.NewNotFoundError("song not found", "link.GetLinks", details)
+		}
+	}
+
+	return links, nil
+}
+
+// AddLink adds the link to the song and returns all links of the song.
+// The first link of the song becomes primary, as well as the link with the Primary flag
+func (r *Link) AddLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error) {
+	slog.Debug("add song link", "data", fmt.Sprintf("%+v", link))
+
+	var links []*dto.SongLink
+	err := execTx(ctx, r.db, "link.AddLink", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, link.SongID, "link.AddLink"); err != nil {
+			return err
+		}
+
+		if link.Primary {
+			if err := demotePrimaryLink(ctx, tx, link.SongID, "link.AddLink"); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			INSERT INTO song_links (song_id, platform, url, is_primary)
+			VALUES ($1, $2, $3, $4 OR NOT EXISTS (SELECT 1 FROM song_links WHERE song_id = $1 AND is_primary))
+			RETURNING id, is_primary`
+
+		err := tx.QueryRowContext(ctx, query, link.SongID, link.Platform, link.URL, link.Primary).Scan(&link.ID, &link.Primary)
+		if err != nil {
+
+			if isPgError(err, uniqueViolationCode) {
+				details := fmt.Sprintf("url=%s", link.URL)
+				return dto.NewConflictError("song already has the link", "link.AddLink", details)
+			}
+
+			return wrapQueryExecError("link.AddLink", err)
+		}
+
+		links, err = getSongLinks(ctx, tx, link.SongID)
+		return err
+	})
+
+	return links, err
+}
+
+// UpdateLink changes the url of the link and returns all links of the song.
+// The platform of the link is detected again, the link becomes primary if the Primary flag is set
+func (r *Link) UpdateLink(ctx context.Context, link *model.SongLink) ([]*dto.SongLink, error) {
+	slog.Debug("update song link", "data", fmt.Sprintf("%+v", link))
+
+	var links []*dto.SongLink
+	err := execTx(ctx, r.db, "link.UpdateLink", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, link.SongID, "link.UpdateLink"); err != nil {
+			return err
+		}
+
+		if link.Primary {
+			if err := demotePrimaryLink(ctx, tx, link.SongID, "link.UpdateLink"); err != nil {
+				return err
+			}
+		}
+
+		query := `
+			UPDATE song_links SET
+				url = COALESCE(NULLIF($3, ''), url),
+				platform = COALESCE(NULLIF($4, ''), platform),
+				is_primary = is_primary OR $5
+			WHERE id = $1 AND song_id = $2`
+
+		result, err := tx.ExecContext(ctx, query, link.ID, link.SongID, link.URL, link.Platform, link.Primary)
+		if err != nil {
+
+			if isPgError(err, uniqueViolationCode) {
+				details := fmt.Sprintf("url=%s", link.URL)
+				return dto.NewConflictError("song already has the link", "link.UpdateLink", details)
+			}
+
+			return wrapQueryExecError("link.UpdateLink", err)
+		}
+
+		affectedCount, err := result.RowsAffected()
+		if err != nil {
+			return wrapQueryExecError("link.UpdateLink", err)
+		}
+
+		if affectedCount == 0 {
+			details := fmt.Sprintf("song_id=%d link_id=%d", link.SongID, link.ID)
+			return dto.NewNotFoundError("link not found", "link.UpdateLink", details)
+		}
+
+		links, err = getSongLinks(ctx, tx, link.SongID)
+		return err
+	})
+
+	return links, err
+}
+
+// DeleteLink removes the link of the song and returns the remaining links.
+// If the primary link is removed, the oldest remaining link becomes primary
+func (r *Link) DeleteLink(ctx context.Context, songID int64, linkID int64) ([]*dto.SongLink, error) {
+	slog.Debug("delete song link", "song_id", songID, "link_id", linkID)
+
+	var links []*dto.SongLink
+	err := execTx(ctx, r.db, "link.DeleteLink", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, songID, "link.DeleteLink"); err != nil {
+			return err
+		}
+
+		var wasPrimary bool
+		query := "DELETE FROM song_links WHERE id = $1 AND song_id = $2 RETURNING is_primary"
+
+		if err := tx.QueryRowContext(ctx, query, linkID, songID).Scan(&wasPrimary); err != nil {
+
+			if err == sql.ErrNoRows {
+				details := fmt.Sprintf("song_id=%d link_id=%d", songID, linkID)
+				return dto.NewNotFoundError("link not found", "link.DeleteLink", details)
+			}
+
+			return wrapQueryExecError("link.DeleteLink", err)
+		}
+
+		if wasPrimary {
+			query = `
+				UPDATE song_links SET is_primary = true
+				WHERE id = (SELECT id FROM song_links WHERE song_id = $1 ORDER BY created_at, id LIMIT 1)`
+
+			if _, err := tx.ExecContext(ctx, query, songID); err != nil {
+				return wrapQueryExecError("link.DeleteLink", err)
+			}
+		}
+
+		var err error
+		links, err = getSongLinks(ctx, tx, songID)
+		return err
+	})
+
+	return links, err
+}
+
+/// ------------ Helpers ------------ ///
+
+// setPrimaryLink makes the url the primary link of the song, the link is added if the song hasn't got it
+func setPrimaryLink(ctx context.Context, tx dbContext, songID int64, url string, source string) error {
+	query := "UPDATE song_links SET is_primary = false WHERE song_id = $1 AND is_primary AND url <> $2"
+	if _, err := tx.ExecContext(ctx, query, songID, url); err != nil {
+		return wrapQueryExecError(source, err)
+	}
+
+	query = `
+		INSERT INTO song_links (song_id, platform, url, is_primary)
+		VALUES ($1, $2, $3, true)
+		ON CONFLICT (song_id, url) DO UPDATE SET is_primary = true`
+
+	if _, err := tx.ExecContext(ctx, query, songID, model.DetectPlatform(url), url); err != nil {
+		return wrapQueryExecError(source, err)
+	}
+
+	return nil
+}
+
+// demotePrimaryLink unsets the primary flag of the current primary link of the song
+func demotePrimaryLink(ctx context.Context, tx dbContext, songID int64, source string) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE song_links SET is_primary = false WHERE song_id = $1 AND is_primary", songID); err != nil {
+		return wrapQueryExecError(source, err)
+	}
+	return nil
+}
+
+func getSongLinks(ctx context.Context, db dbContext, songID int64) ([]*dto.SongLink, error) {
+	query := `
+		SELECT id, song_id, platform, url, is_primary
+		FROM song_links
+		WHERE song_id = $1
+		ORDER BY is_primary DESC, created_at, id`
+
+	links := make([]*dto.SongLink, 0)
+	if err := db.SelectContext(ctx, &links, query, songID); err != nil {
+		return nil, wrapQueryExecError("link.getSongLinks", err)
+	}
+
+	return links, nil
+}
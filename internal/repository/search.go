@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+)
+
+// Search object adapter for the search queries over the songs tables
+type Search struct {
+	db dbContext
+}
+
+func NewSearch(db dbContext) *Search {
+	return &Search{db}
+}
+
+// searchVectorColumns maps the search configurations to the song_details columns with the lyrics vectors
+var searchVectorColumns = map[string]string{
+	"russian": "text_tsv_russian",
+	"english": "text_tsv_english",
+	"simple":  "text_tsv_simple",
+}
+
+/// ------------ Interface ------------ ///
+
+// SearchLyrics returns the page of songs which lyrics match the web search query [e.g. "red door" -black],
+// ordered by the rank. Each song has the headline: the best matching couplet with the highlighted words
+func (r *Search) SearchLyrics(ctx context.Context, q string, lang string, limit int64, offset int64) ([]*dto.LyricsSearchResult, error) {
+	slog.Debug("search lyrics", "q", q, "lang", lang, "limit", limit, "offset", offset)
+
+	column, ok := searchVectorColumns[lang]
+	if !ok {
+		details := fmt.Sprintf("lang=%s", lang)
+		return nil, dto.NewError(400, "unsupported search language", "search.SearchLyrics", details, nil)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			songs.id AS song_id,
+			artists.name AS group_name,
+			songs.song_name,
+			ts_rank(song_details.%[1]s, search.query) AS rank,
+			(
+				SELECT ts_headline(search.config, couplet, search.query, 'StartSel=<b>, StopSel=</b>, HighlightAll=true')
+				FROM regexp_split_to_table(lyrics_normalize(song_details.text), E'\n\n') AS couplet
+				ORDER BY ts_rank(to_tsvector(search.config, couplet), search.query) DESC
+				LIMIT 1
+			) AS headline
+		FROM songs
+		JOIN artists ON artists.id = songs.artist_id
+		JOIN song_details ON song_details.song_id = songs.id
+		CROSS JOIN (SELECT $1::regconfig AS config, websearch_to_tsquery($1::regconfig, $2) AS query) AS search
+		WHERE song_details.%[1]s @@ search.query
+		ORDER BY rank DESC, songs.id
+		LIMIT $3 OFFSET $4`, column)
+
+	results := make([]*dto.LyricsSearchResult, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &results, query, lang, q, limit, offset); err != nil {
+		return nil, wrapQueryExecError("search.SearchLyrics", err)
+	}
+
+	return results, nil
+}
+
+// Suggest returns up to limit groups or songs (depends on the kind) which names start with the prefix.
+// The exact matches go first, then the popular ones: groups with more songs and songs on more platforms,
+// then the shorter names, which are closer to the prefix
+func (r *Search) Suggest(ctx context.Context, kind string, prefix string, limit int64) ([]*dto.Suggestion, error) {
+	slog.Debug("suggest", "kind", kind, "prefix", prefix, "limit", limit)
+
+	var query string
+	switch kind {
+	case "group":
+		query = `
+			SELECT artists.id, artists.name AS value, count(songs.id) AS popularity
+			FROM artists
+			LEFT JOIN songs ON songs.artist_id = artists.id
+			WHERE lower(artists.name) LIKE $1
+			GROUP BY artists.id
+			ORDER BY lower(artists.name) = $2 DESC, popularity DESC, length(artists.name), artists.name
+			LIMIT $3`
+	case "song":
+		query = `
+			SELECT songs.id, songs.song_name AS value, artists.name AS group_name, count(song_links.id) AS popularity
+			FROM songs
+			JOIN artists ON artists.id = songs.artist_id
+			LEFT JOIN song_links ON song_links.song_id = songs.id
+			WHERE lower(songs.song_name) LIKE $1
+			GROUP BY songs.id, artists.name
+			ORDER BY lower(songs.song_name) = $2 DESC, popularity DESC, length(songs.song_name), songs.song_name
+			LIMIT $3`
+	default:
+		details := fmt.Sprintf("kind=%s", kind)
+		return nil, dto.NewError(400, "unsupported suggestion kind", "search.Suggest", details, nil)
+	}
+
+	prefix = strings.ToLower(prefix)
+	pattern := escapeLikePattern(prefix) + "%"
+
+	suggestions := make([]*dto.Suggestion, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &suggestions, query, pattern, prefix, limit); err != nil {
+		return nil, wrapQueryExecError("search.Suggest", err)
+	}
+
+	return suggestions, nil
+}
+
+/// ------------ Helpers ------------ ///
+
+// escapeLikePattern escapes the wildcards of the LIKE pattern, so the value is matched literally
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
@@ -5,15 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
-	"reflect"
-	"strconv"
 	"strings"
-	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/domain/model"
-	"github.com/jmoiron/sqlx"
+	"github.com/amicie-monami/music-library/internal/filter"
 )
 
 // dbContext describes the database context.
@@ -23,6 +20,7 @@ type dbContext interface {
 	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 }
 
 // Song object adapter for database operations with songs tables
@@ -36,109 +34,113 @@ func NewSong(db dbContext) *Song {
 
 /// ------------ Interface ------------ ///
 
-// Tx execute the transaction, the action of which described in the txActions
-func (r *Song) Tx(ctx context.Context, txActions func() error) error {
-	db, ok := r.db.(*sqlx.DB)
-	if !ok {
-		debugMessage := fmt.Sprintf("execute the tx, unsupport database type: %v", reflect.TypeOf(r.db))
-		return dto.NewError(500, "internal server error", "song.Tx", nil, debugMessage)
-	}
+func (r *Song) Create(ctx context.Context, song *model.Song) error {
+	slog.Debug("create song", "data", fmt.Sprintf("%+v", song))
 
-	tx, err := db.BeginTxx(ctx, &sql.TxOptions{})
-	if err != nil {
-		debugMessage := fmt.Errorf("failed to begin the transaction: %s", err)
-		return dto.NewError(500, "internal server error", "song.Tx", nil, debugMessage)
-	}
+	//the artist is created with the song if the library doesn't know the group yet
+	query := `
+		WITH artist AS (` + upsertArtistQuery + `)
+		INSERT INTO songs (artist_id, song_name)
+		SELECT id, $2 FROM artist
+		RETURNING id, artist_id`
 
-	//change the context of the repository database to a transaction
-	r.db = tx
-	defer func() {
-		//after transactions are executed, return the database context to the instance
-		r.db = db
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		} else if err != nil {
-			tx.Rollback()
-		}
-	}()
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, song.Group, song.Name).Scan(&song.ID, &song.ArtistID); err != nil {
 
-	//make a payload
-	if err = txActions(); err != nil {
-		return err
-	}
+		if isPgError(err, uniqueViolationCode) {
+			details := fmt.Sprintf("song=%s, group=%s", song.Name, song.Group)
+			return dto.NewConflictError("song already exists", "song.Create", details)
+		}
 
-	//commits the changes
-	if err = tx.Commit(); err != nil {
-		debugMessage := fmt.Errorf("failed to commit the transaction: %s", err)
-		return dto.NewError(500, "internal server error", "song.Tx", nil, debugMessage)
+		return wrapQueryExecError("song.Create", err)
 	}
 
 	return nil
 }
 
-func (r *Song) Create(ctx context.Context, song *model.Song) error {
-	slog.Debug("create song", "data", fmt.Sprintf("%+v", song))
+// GetSongs returns the page of songs. The page is selected by the offset or by the cursor,
+// which is the position after the last song of the previous page. The total number of songs
+// matching the filter is counted exactly, estimated by the planner or skipped depending on the total mode
+func (r *Song) GetSongs(ctx context.Context, aggregation map[string]any) (*dto.GetSongsResponse, error) {
+	slog.Debug("get song", "aggregation data=", aggregation)
 
-	query := squirrel.
-		Insert("songs").
-		Columns(
-			"group_name",
-			"song_name",
-		).
-		Values(song.Group, song.Name).
-		PlaceholderFormat(squirrel.Dollar).
-		Suffix("RETURNING id")
+	//setup aggragation filters
+	filterNode, _ := aggregation["filter"].(filter.Node)
+	fields, _ := aggregation["fields"].(string)
 
-	sql, args := query.MustSql()
-	if err := r.db.QueryRowContext(ctx, sql, args...).Scan(&song.ID); err != nil {
-		return wrapQueryExecError("song.Create", err)
+	columns := buildGetSongsColumnNames(fields)
+	whereExpr, err := buildGetSongsWhereExpr(filterNode)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
-
-func (r *Song) GetSongs(ctx context.Context, aggregation map[string]any) ([]*dto.SongWithDetails, error) {
-	slog.Debug("get song", "aggregation data=", aggregation)
+	//the fuzzy matched songs get the similarity to the search terms
+	similarity := buildSimilarityExpr(filterNode)
 
-	//setup aggragation filters
-	columns := buildGetSongsColumnNames(aggregation["fields"].(string))
-	whereExpr, err := buildGetSongsWhereExpr(aggregation["filter"].(map[string]any))
+	sort, _ := aggregation["sort"].([]model.SortField)
+	sortKeys, err := buildGetSongsSortKeys(sort, similarity)
 	if err != nil {
 		return nil, err
 	}
 
-	//mark the body of the sql query
-	queryBuilder := squirrel.
-		Select(columns...).
-		From("songs").
-		Join("song_details ON songs.id = song_details.song_id").
+	//mark the body of the sql query, the version of the song is the entity tag of the list item
+	queryBuilder := selectSongs(append(columns, "songs.version")...).
 		Where(whereExpr).
-		OrderBy("song_id").
-		PlaceholderFormat(squirrel.Dollar)
-
-	//setup pagination filters
-	if aggregation["limit"] != "" {
-		queryBuilder = queryBuilder.Limit(uint64(aggregation["limit"].(int64)))
-	} else {
-		queryBuilder = queryBuilder.Limit(1000)
+		OrderBy(sortKeysOrderBy(sortKeys)...)
+
+	if similarity != nil {
+		queryBuilder = queryBuilder.Column(squirrel.Alias(similarity, "similarity"))
 	}
-	if aggregation["offset"] != "" {
-		queryBuilder = queryBuilder.Offset(uint64(aggregation["offset"].(int64)))
+	queryBuilder = queryBuilder.Column(buildCursorKeyColumn(sortKeys))
+
+	//the page starts after the cursor position
+	if cursor, _ := aggregation["cursor"].(string); cursor != "" {
+		values, err := decodeSongsCursor(cursor, sortKeys)
+		if err != nil {
+			return nil, err
+		}
+		queryBuilder = queryBuilder.Where(buildCursorCondition(sortKeys, values))
+	}
+
+	//setup pagination filters, one more song is selected to find out whether the next page exists
+	limit, ok := aggregation["limit"].(int64)
+	if !ok {
+		limit = 1000
+	}
+	queryBuilder = queryBuilder.Limit(uint64(limit + 1))
+
+	if offset, _ := aggregation["offset"].(int64); offset > 0 {
+		queryBuilder = queryBuilder.Offset(uint64(offset))
 	}
 
 	//build sql query
 	query, args := queryBuilder.MustSql()
 
 	//execution
-	songs := make([]*dto.SongWithDetails, 0)
-	if err := r.db.SelectContext(ctx, &songs, query, args...); err != nil {
+	rows := make([]*songsPageRow, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &rows, query, args...); err != nil {
 		return nil, wrapQueryExecError("song.GetSongs", err)
 	}
 
-	fmt.Println(query)
+	response := &dto.GetSongsResponse{Songs: make([]*dto.SongWithDetails, 0, len(rows))}
+
+	if int64(len(rows)) > limit {
+		rows = rows[:limit]
+		response.HasMore = true
+
+		nextCursor := encodeSongsCursor(sortKeys, rows[len(rows)-1].CursorKey)
+		response.NextCursor = &nextCursor
+	}
+
+	for _, row := range rows {
+		response.Songs = append(response.Songs, &row.SongWithDetails)
+	}
+
+	total, _ := aggregation["total"].(string)
+	if err := r.countSongs(ctx, whereExpr, total, response); err != nil {
+		return nil, err
+	}
 
-	return songs, nil
+	return response, nil
 }
 
 func (r *Song) GetSongText(ctx context.Context, id int64) (*string, error) {
@@ -152,12 +154,12 @@ func (r *Song) GetSongText(ctx context.Context, id int64) (*string, error) {
 		MustSql()
 
 	songText := new(*string)
-	if err := r.db.QueryRowContext(ctx, query, args...).Scan(songText); err != nil {
+	if err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(songText); err != nil {
 
 		if err == sql.ErrNoRows {
 			message := "could not found the song"
 			details := fmt.Sprintf("id=%d", id)
-			return nil, dto.NewError(400, message, "song.GetSongText", details, nil)
+			return nil, dto.NewNotFoundError(message, "song.GetSongText", details)
 		}
 
 		return nil, wrapQueryExecError("song.GetSongs", err)
@@ -166,6 +168,8 @@ func (r *Song) GetSongText(ctx context.Context, id int64) (*string, error) {
 	return *songText, nil
 }
 
+// GetSongWithDetails returns the song by the exact group and song names. The legacy duplicates
+// of the song are ignored until they are merged
 func (r *Song) GetSongWithDetails(ctx context.Context, group string, title string) (*dto.SongWithDetails, error) {
 	slog.Debug("get song", "group", group, "title", title)
 	var songWithDetails dto.SongWithDetails
@@ -174,25 +178,29 @@ func (r *Song) GetSongWithDetails(ctx context.Context, group string, title strin
 		Select(
 			"song_id",
 			"release_date",
-			"link",
+			songsColumnExprs["link"],
 			"text",
+			"songs.version",
 		).
 		From("song_details").
 		Join("songs on songs.id = song_id").
+		Join("artists on artists.id = songs.artist_id").
 		Where(squirrel.Eq{
-			"songs.group_name": group,
-			"songs.song_name":  title,
+			"artists.name":    group,
+			"songs.song_name": title,
 		}).
+		OrderBy("songs.legacy_duplicate", "songs.id").
+		Limit(1).
 		PlaceholderFormat(squirrel.Dollar).
 		MustSql()
 
-	err := r.db.GetContext(ctx, &songWithDetails, query, args...)
+	err := executor(ctx, r.db).GetContext(ctx, &songWithDetails, query, args...)
 	if err != nil {
 
 		if err == sql.ErrNoRows {
 			message := "could not found the song"
 			details := fmt.Sprintf("song=%s, group=%s", title, group)
-			return nil, dto.NewError(400, message, "song.GetSongText", details, nil)
+			return nil, dto.NewNotFoundError(message, "song.GetSongText", details)
 		}
 
 		return nil, wrapQueryExecError("song.GetSongWithDetails", err)
@@ -207,50 +215,136 @@ func (r *Song) UpdateSong(ctx context.Context, song *model.Song) error {
 		return dto.NewError(500, "internal server error", "song.UpdateSong", nil, "song is nil")
 	}
 
+	//the song is moved to the artist with the new group name, the artist is created if needed
+	if song.Group != "" {
+		if err := executor(ctx, r.db).QueryRowContext(ctx, upsertArtistQuery, song.Group).Scan(&song.ArtistID); err != nil {
+			return wrapQueryExecError("song.UpdateSong", err)
+		}
+	}
+
 	table := "songs"
 	primaryKeyEqauls := squirrel.Eq{"id": song.ID}
 
 	setMap := map[string]any{
-		"group_name": song.Group,
-		"song_name":  song.Name,
+		"artist_id": song.ArtistID,
+		"song_name": song.Name,
 	}
 
-	affectedCount, err := updateRowContext(ctx, r.db, table, primaryKeyEqauls, setMap)
+	affectedCount, err := updateRowContext(ctx, executor(ctx, r.db), table, primaryKeyEqauls, setMap)
 	if err != nil {
+
+		if isPgError(err, uniqueViolationCode) {
+			details := fmt.Sprintf("id=%d, the artist already has the song with the same name", song.ID)
+			return dto.NewConflictError("song already exists", "song.UpdateSong", details)
+		}
+
 		return wrapQueryExecError("song.UpdateSong", err)
 	}
 
 	if affectedCount == 0 {
 		details := fmt.Sprintf("id=%d", song.ID)
-		return dto.NewError(400, "song not found", "song.UpdateSong", details, nil)
+		return dto.NewNotFoundError("song not found", "song.UpdateSong", details)
 	}
 
 	return nil
 }
 
+// UpdateSongDetails updates the details of the song, the link becomes the primary link of the song
 func (r *Song) UpdateSongDetails(ctx context.Context, details *model.SongDetail) error {
 	slog.Debug("update song details", "data", fmt.Sprintf("%+v", details))
 
-	table := "song_details"
-	primaryKeyEqauls := squirrel.Eq{"song_id": details.SongID}
+	return execTx(ctx, r.db, "song.UpdateSongDetails", func(tx dbContext) error {
+		//the song is locked before its details, the same order as in the other updates of the song
+		if err := lockSong(ctx, tx, details.SongID, "song.UpdateSongDetails"); err != nil {
+			return err
+		}
 
-	setMap := map[string]any{
-		"text":         details.Text,
-		"link":         details.Link,
-		"release_date": details.ReleaseDate,
-	}
+		hasLink := details.Link != nil && *details.Link != ""
+		if hasLink {
+			if err := setPrimaryLink(ctx, tx, details.SongID, *details.Link, "song.UpdateSongDetails"); err != nil {
+				return err
+			}
+		}
 
-	affectedCount, err := updateRowContext(ctx, r.db, table, primaryKeyEqauls, setMap)
-	if err != nil {
-		return wrapQueryExecError("song.UpdateSong", err)
+		table := "song_details"
+		primaryKeyEqauls := squirrel.Eq{"song_id": details.SongID}
+
+		setMap := map[string]any{
+			"text":         details.Text,
+			"release_date": details.ReleaseDate,
+		}
+
+		//the zero values aren't updated, so the cleared fields are set to the null expression
+		clearLink := false
+		for _, field := range details.Clear {
+			switch field {
+			case model.SongDetailLink:
+				clearLink = true
+			case model.SongDetailReleaseDate, model.SongDetailText:
+				setMap[field] = squirrel.Expr("NULL")
+			}
+		}
+
+		//the links of the song are kept, the song only loses its primary link
+		if clearLink {
+			if err := demotePrimaryLink(ctx, tx, details.SongID, "song.UpdateSongDetails"); err != nil {
+				return err
+			}
+		}
+
+		affectedCount, err := updateRowContext(ctx, tx, table, primaryKeyEqauls, setMap)
+		if err != nil {
+			return wrapQueryExecError("song.UpdateSongDetails", err)
+		}
+
+		//the song exists, so nothing has been updated only if nothing has been sent
+		if affectedCount == 0 && !hasLink && !clearLink {
+			details := fmt.Sprintf("id=%d", details.SongID)
+			return dto.NewNotFoundError("song not found", "song.UpdateSongDetails", details)
+		}
+
+		return nil
+	})
+}
+
+// GetSong returns the song with the details by the id
+func (r *Song) GetSong(ctx context.Context, id int64) (*dto.SongWithDetails, error) {
+	slog.Debug("get song", "id", id)
+
+	query, args := selectSongs(append(buildGetSongsColumnNames(""), "songs.version")...).
+		Where(squirrel.Eq{"songs.id": id}).
+		MustSql()
+
+	var song dto.SongWithDetails
+	if err := executor(ctx, r.db).GetContext(ctx, &song, query, args...); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("id=%d", id)
+			return nil, dto.NewNotFoundError("song not found", "song.GetSong", details)
+		}
+
+		return nil, wrapQueryExecError("song.GetSong", err)
 	}
 
-	if affectedCount == 0 {
-		details := fmt.Sprintf("id=%d", details.SongID)
-		return dto.NewError(400, "song not found", "song.UpdateSong", details, nil)
+	return &song, nil
+}
+
+// LockSongVersion locks the song until the end of the transaction and returns its version
+func (r *Song) LockSongVersion(ctx context.Context, id int64) (int64, error) {
+	slog.Debug("lock song version", "id", id)
+
+	var version int64
+	if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT version FROM songs WHERE id = $1 FOR UPDATE", id).Scan(&version); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("id=%d", id)
+			return 0, dto.NewNotFoundError("song not found", "song.LockSongVersion", details)
+		}
+
+		return 0, wrapQueryExecError("song.LockSongVersion", err)
 	}
 
-	return nil
+	return version, nil
 }
 
 func (r *Song) Delete(ctx context.Context, id int64) error {
@@ -262,7 +356,7 @@ func (r *Song) Delete(ctx context.Context, id int64) error {
 		PlaceholderFormat(squirrel.Dollar).
 		MustSql()
 
-	result, err := r.db.ExecContext(ctx, query, args...)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, args...)
 	if err != nil {
 		return wrapQueryExecError("song.Delete", err)
 	}
@@ -274,7 +368,7 @@ func (r *Song) Delete(ctx context.Context, id int64) error {
 
 	if affectedCount == 0 {
 		details := fmt.Sprintf("id=%d", id)
-		return dto.NewError(400, "song not found", "song.Delete", details, nil)
+		return dto.NewNotFoundError("song not found", "song.Delete", details)
 	}
 
 	return nil
@@ -282,199 +376,49 @@ func (r *Song) Delete(ctx context.Context, id int64) error {
 
 /// ------------ Helpers ------------ ///
 
+// wrapQueryExecError wraps the database error, the lost connection and the timeout make the service unavailable
 func wrapQueryExecError(source string, err error) *dto.Error {
-	debugMsg := fmt.Errorf("database error: %v", err)
-	return dto.NewError(500, "internal server error", source, nil, debugMsg)
-}
-
-func buildGetSongsColumnNames(columns string) []string {
-	if columns == "" {
-		return []string{
-			"song_id",
-			"group_name",
-			"song_name",
-			"release_date",
-			"link",
-			"text",
-		}
-	}
-	return strings.Split(columns, " ")
-}
-
-// conditionBuilderFunc defines a function type that constructs SQL conditions.
-type conditionBuilderFunc func(string) (squirrel.Sqlizer, error)
-
-// buildGetSongsWhereExpr builds where expr
-func buildGetSongsWhereExpr(filter map[string]any) (squirrel.Sqlizer, error) {
-	conditionResolvers := map[string]conditionBuilderFunc{
-		"song_id":      buildSongIDCondition,
-		"song_name":    buildSongNameCondition,
-		"groups":       buildGroupsCondition,
-		"link":         buildLinkCondition,
-		"release_date": buildReleaseDateCondition,
-		"text":         buildSongTextCondition,
-	}
-
-	return buildParamBasedAndConditions(filter, conditionResolvers)
-}
-
-// buildSongIDCondition build comparison expression on song_id column [e.g. song_id >= 12]
-func buildSongIDCondition(paramSongID string) (squirrel.Sqlizer, error) {
-	if paramSongID == "" {
-		return nil, dto.NewError(400, "missing value in song_id param ", "buildSongIDCondition", nil, nil)
+	debugMsg := fmt.Errorf("database error: %w", err)
+	if isUnavailableError(err) {
+		return dto.NewUnavailableError("database is unavailable", source, debugMsg)
 	}
 
-	var err error
-	songIDConstraint := strings.Split(paramSongID, " ")
-
-	//assume that parameter hasn't comparison operator
-	comparison := "="
-	songID := songIDConstraint[0]
-
-	//if split returns two elements, that parameter must contain a comparision operator.
-	//Change default "=" on param's operator
-	if len(songIDConstraint) == 2 {
-		comparison, err = parseComparisonOperatorInSongIDParam(songIDConstraint[0])
-		if err != nil {
-			return nil, err
-		}
-
-		songID = songIDConstraint[1]
-
-		//if param has 2 and more values
-	} else if len(songIDConstraint) > 2 {
-		and := squirrel.And{}
-		//range for pairs [operator constraint, operator constraint]
-		for idx := 0; idx < len(songIDConstraint); idx += 2 {
-			comparison, err = parseComparisonOperatorInSongIDParam(songIDConstraint[idx])
-			if err != nil {
-				return nil, err
-			}
-
-			songID = songIDConstraint[idx+1]
-			and = append(and, squirrel.Expr(fmt.Sprintf("song_id %s ?", comparison), songID))
-		}
-
-		return and, nil
+	//the transaction is retried by the TxManager, the client gets the conflict if the retries are over
+	if isRetryableTxError(err) {
+		conflict := dto.NewConflictError("transaction conflicts with the concurrent one", source, "the request can be retried")
+		conflict.DebugMsg = debugMsg
+		return conflict
 	}
-
-	//validation
-	if _, err := strconv.ParseInt(songID, 10, 64); err != nil {
-		return nil, dto.NewError(400, "song_id comstraint must be a num", "buildSongIDCondition", songID, nil)
-	}
-
-	//build comparison expression fot the song_id column
-	return squirrel.Expr(fmt.Sprintf("song_id %s ?", comparison), songID), nil
-}
-
-func parseComparisonOperatorInSongIDParam(operator string) (string, error) {
-	comparison := parseComparison(operator)
-	if comparison == "" {
-		return "", dto.NewError(400, "invalid comparison operator in song_id param", "buildSongIDCondition", operator, nil)
-	}
-	return comparison, nil
-}
-
-// buildSongNameCondition builds an ILIKE expression (or multiple ILIKEs
-// associated with the OR operator) for the "song_name" column.
-func buildSongNameCondition(paramSongName string) (squirrel.Sqlizer, error) {
-	return buildILikeCondition("song_name", paramSongName)
-}
-
-// buildGroupsCondition builds equals expression (or muliplie equals associated with the OR operator)
-// for the "group_name" column
-func buildGroupsCondition(paramGroups string) (squirrel.Sqlizer, error) {
-	if paramGroups == "" {
-		return nil, dto.NewError(400, "missing value in groups param ", "buildSongIDCondition", nil, nil)
-	}
-	groups := strings.Split(paramGroups, " ")
-	for idx := range groups {
-		groups[idx] = strings.ReplaceAll(groups[idx], "_", " ")
-	}
-	return squirrel.Eq{"group_name": groups}, nil
+	return dto.NewError(500, "internal server error", source, nil, debugMsg)
 }
 
-// buildSongNameCondition builds an ILIKE expression (or multiple ILIKEs
-// associated with the OR operator) for the "link" column.
-func buildLinkCondition(paramLink string) (squirrel.Sqlizer, error) {
-	return buildILikeCondition("link", paramLink)
+// upsertArtistQuery returns the id of the artist with the name $1, the artist is created if it doesn't exist
+const upsertArtistQuery = `
+	INSERT INTO artists (name) VALUES ($1)
+	ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+	RETURNING id`
+
+// songsColumnExprs maps the column names of the songs list to the select expressions
+var songsColumnExprs = map[string]string{
+	"group_name": "artists.name AS group_name",
+	"link":       "(SELECT url FROM song_links WHERE song_links.song_id = songs.id AND song_links.is_primary) AS link",
+	"tags": `COALESCE((
+		SELECT json_agg(tags.name ORDER BY tags.name)
+		FROM song_tags JOIN tags ON tags.id = song_tags.tag_id
+		WHERE song_tags.song_id = songs.id
+	), '[]') AS tags`,
 }
 
-// buildReleaseDateCondition builds constraints for the "release_date" column depending on the content of the paramter
-//   - Comparision expr (if the param is [comparison operator]+[date] kind)
-//   - Equals expr (if the parameter has only one date)
-//   - Between expr(if the parameteris of the [date]-[date] kind)
-func buildReleaseDateCondition(paramReleaseDate string) (squirrel.Sqlizer, error) {
-	if paramReleaseDate == "" {
-		return nil, dto.NewError(400, "missing value in release_date param ", "buildSongIDCondition", nil, nil)
-	}
-
-	dateConstraint := strings.Split(paramReleaseDate, " ")
-
-	//release date parameter has param=[operator]+[date] kind
-	if len(dateConstraint) == 2 {
-		date, err := parseDateInReleaseDateParam(dateConstraint[1])
-		if err != nil {
-			return nil, err
-		}
-
-		comparison, err := parseComparisonOperatorInReleaseDateParam(dateConstraint[0])
-		if err != nil {
-			return nil, err
-		}
-
-		return squirrel.Expr(fmt.Sprintf("release_date %s ?", comparison), date), nil
-	}
-
-	dates := strings.Split(paramReleaseDate, "-")
-	//release date parameter has param=[date] kind
-	if len(dates) == 1 {
-
-		date, err := parseDateInReleaseDateParam(dates[0])
-		if err != nil {
-			return nil, err
-		}
-
-		return squirrel.Eq{"release_date": date}, nil
+func buildGetSongsColumnNames(columns string) []string {
+	if columns == "" {
+		columns = "song_id group_name song_name release_date link text"
 	}
 
-	//release date parameter has param=[date]-[date] kind
-	if len(dates) == 2 {
-
-		startDate, err := parseDateInReleaseDateParam(dates[0])
-		if err != nil {
-			return nil, err
-		}
-
-		endDate, err := parseDateInReleaseDateParam(dates[1])
-		if err != nil {
-			return nil, err
+	columnNames := strings.Split(columns, " ")
+	for idx := range columnNames {
+		if expr, ok := songsColumnExprs[columnNames[idx]]; ok {
+			columnNames[idx] = expr
 		}
-
-		return squirrel.Expr("release_date BETWEEN ? AND ?", startDate, endDate), nil
 	}
-
-	return nil, dto.NewError(400, "failed to parse release_date param", "buildReleaseDateCondition", paramReleaseDate, nil)
-}
-
-func parseDateInReleaseDateParam(dateStr string) (time.Time, error) {
-	date, err := time.Parse("02.01.2006", dateStr)
-	if err != nil {
-		return time.Time{}, dto.NewError(400, "could not parse the release_date, expected format was dd.mm.yyyy", "buildReleaseDateCondition", dateStr, nil)
-	}
-	return date, nil
-}
-
-func parseComparisonOperatorInReleaseDateParam(operator string) (string, error) {
-	comparison := parseComparison(operator)
-	if comparison == "" {
-		return "", dto.NewError(400, "invalid comparison operator in release_date param", "buildReleaseDateCondition", operator, nil)
-	}
-	return comparison, nil
-}
-
-// buildSongTextCondition builds an ILIKE expression (or multiple ILIKEs
-// associated with the OR operator) for the "text" column.
-func buildSongTextCondition(paramSongText string) (squirrel.Sqlizer, error) {
-	return buildILikeCondition("text", paramSongText)
+	return columnNames
 }
@@ -0,0 +1,325 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// GetDuplicates returns the groups of the songs with the same normalized group and song names ordered by the
+// oldest song of the group. The group matches exactly if all its songs have the same names, the match param
+// takes one value from [exact, normalized], the empty match returns both kinds of the groups
+func (r *Song) GetDuplicates(ctx context.Context, match string, limit, offset int64) ([]*dto.SongDuplicates, error) {
+	slog.Debug("get song duplicates", "match", match, "limit", limit, "offset", offset)
+
+	exactExpr := "count(DISTINCT (artists.name, songs.song_name)) = 1"
+
+	queryBuilder := squirrel.
+		Select(
+			fmt.Sprintf("CASE WHEN %s THEN '%s' ELSE '%s' END AS match", exactExpr, model.DuplicateExact, model.DuplicateNormalized),
+			"(array_agg(artists.name ORDER BY songs.id))[1] AS group_name",
+			"(array_agg(songs.song_name ORDER BY songs.id))[1] AS song_name",
+			`json_agg(json_build_object(
+				'song_id', songs.id,
+				'group', artists.name,
+				'song', songs.song_name
+			) ORDER BY songs.id) AS songs`,
+		).
+		From("songs").
+		Join("artists ON artists.id = songs.artist_id").
+		GroupBy("song_name_normalize(artists.name)", "song_name_normalize(songs.song_name)").
+		Having("count(*) > 1").
+		OrderBy("min(songs.id)").
+		Limit(uint64(limit)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	switch match {
+	case model.DuplicateExact:
+		queryBuilder = queryBuilder.Having(exactExpr)
+	case model.DuplicateNormalized:
+		queryBuilder = queryBuilder.Having("NOT " + exactExpr)
+	}
+
+	if offset > 0 {
+		queryBuilder = queryBuilder.Offset(uint64(offset))
+	}
+
+	query, args := queryBuilder.MustSql()
+
+	duplicates := make([]*dto.SongDuplicates, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &duplicates, query, args...); err != nil {
+		return nil, wrapQueryExecError("song.GetDuplicates", err)
+	}
+
+	return duplicates, nil
+}
+
+// MergeSongs merges the duplicates into the survivor song in one transaction and deletes them. The empty details
+// of the survivor are taken from the duplicates in their order, the links, the tags, the album tracks and the missing
+// lyrics translations are moved to the survivor. The taken text keeps its revisions and timing. The survivor gets
+// the place of the song in the uniqueness constraint of the normalized names
+func (r *Song) MergeSongs(ctx context.Context, survivorID int64, duplicateIDs []int64) (*dto.SongWithDetails, error) {
+	slog.Debug("merge songs", "survivor", survivorID, "duplicates", duplicateIDs)
+
+	var song dto.SongWithDetails
+	err := execTx(ctx, r.db, "song.MergeSongs", func(tx dbContext) error {
+		if err := lockDuplicates(ctx, tx, survivorID, duplicateIDs); err != nil {
+			return err
+		}
+
+		if err := mergeSongDetailsWithLyrics(ctx, tx, survivorID, duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		if err := mergeSongLinks(ctx, tx, survivorID, duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		query := `
+			INSERT INTO song_tags (song_id, tag_id)
+			SELECT DISTINCT $1::bigint, tag_id FROM song_tags WHERE song_id = ANY($2)
+			ON CONFLICT DO NOTHING`
+
+		if _, err := tx.ExecContext(ctx, query, survivorID, duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		if err := mergeAlbumTracks(ctx, tx, survivorID, duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		if err := mergeLyricsTranslations(ctx, tx, survivorID, duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM songs WHERE id = ANY($1)", duplicateIDs); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		//the legacy duplicate survives only if the other song with the same name has been merged into it
+		if _, err := tx.ExecContext(ctx, "UPDATE songs SET legacy_duplicate = false WHERE id = $1 AND legacy_duplicate", survivorID); err != nil {
+
+			if isPgError(err, uniqueViolationCode) {
+				details := fmt.Sprintf("id=%d, the song with the same name isn't merged", survivorID)
+				return dto.NewConflictError("song already exists", "song.MergeSongs", details)
+			}
+
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+
+		query, args := selectSongs(buildGetSongsColumnNames("song_id group_name song_name release_date link text tags")...).
+			Where(squirrel.Eq{"songs.id": survivorID}).
+			MustSql()
+
+		if err := tx.GetContext(ctx, &song, query, args...); err != nil {
+			return wrapQueryExecError("song.MergeSongs", err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &song, nil
+}
+
+// lockDuplicates locks the merged songs and checks that they exist and have the same normalized names
+func lockDuplicates(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	query := `
+		SELECT songs.id, song_name_normalize(artists.name) AS group_key, song_name_normalize(songs.song_name) AS song_key
+		FROM songs
+		JOIN artists ON artists.id = songs.artist_id
+		WHERE songs.id = $1 OR songs.id = ANY($2)
+		ORDER BY songs.id
+		FOR UPDATE OF songs`
+
+	rows := make([]struct {
+		ID       int64  `db:"id"`
+		GroupKey string `db:"group_key"`
+		SongKey  string `db:"song_key"`
+	}, 0, len(duplicateIDs)+1)
+
+	if err := tx.SelectContext(ctx, &rows, query, survivorID, duplicateIDs); err != nil {
+		return wrapQueryExecError("song.MergeSongs", err)
+	}
+
+	keys := make(map[int64][2]string, len(rows))
+	for _, row := range rows {
+		keys[row.ID] = [2]string{row.GroupKey, row.SongKey}
+	}
+
+	survivorKey, ok := keys[survivorID]
+	if !ok {
+		details := fmt.Sprintf("id=%d", survivorID)
+		return dto.NewNotFoundError("song not found", "song.MergeSongs", details)
+	}
+
+	for _, id := range duplicateIDs {
+		key, ok := keys[id]
+		if !ok {
+			field := dto.FieldError{Field: "duplicates", Message: fmt.Sprintf("song_id=%d doesn't exist", id)}
+			return dto.NewValidationError("song not found", "song.MergeSongs", field)
+		}
+
+		if key != survivorKey {
+			message := fmt.Sprintf("song_id=%d has group=%s song=%s, but the song id=%d has group=%s song=%s",
+				id, key[0], key[1], survivorID, survivorKey[0], survivorKey[1])
+			return dto.NewValidationError("songs aren't duplicates", "song.MergeSongs", dto.FieldError{Field: "duplicates", Message: message})
+		}
+	}
+
+	return nil
+}
+
+// mergeSongDetailsWithLyrics merges the details of the songs. If the survivor takes the text of the duplicate,
+// it takes the revisions and the timing of the text as well: the revisions of the duplicate go after the revisions
+// of the survivor and before the revision of the merge, the synced lines replace the lines of the survivor
+func mergeSongDetailsWithLyrics(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	donorID, err := getLyricsDonor(ctx, tx, survivorID, duplicateIDs)
+	if err != nil {
+		return err
+	}
+
+	if donorID != 0 {
+		query := `
+			UPDATE lyrics_revisions SET
+				song_id = $1,
+				revision = revision + (SELECT COALESCE(max(revision), 0) FROM lyrics_revisions WHERE song_id = $1)
+			WHERE song_id = $2`
+
+		if _, err := tx.ExecContext(ctx, query, survivorID, donorID); err != nil {
+			return err
+		}
+	}
+
+	if err := mergeSongDetails(ctx, tx, survivorID, duplicateIDs); err != nil {
+		return err
+	}
+
+	if donorID == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM synced_lyrics_lines WHERE song_id = $1", survivorID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE synced_lyrics_lines SET song_id = $1 WHERE song_id = $2", survivorID, donorID)
+	return err
+}
+
+// getLyricsDonor returns the id of the duplicate which text is taken by the survivor without the text, zero if
+// the survivor keeps its text or the duplicates have no text. The duplicate is chosen as by mergeSongDetails
+func getLyricsDonor(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) (int64, error) {
+	query := `
+		SELECT donor.song_id
+		FROM song_details AS donor
+		JOIN song_details AS survivor ON survivor.song_id = $1
+		WHERE donor.song_id = ANY($2)
+			AND NULLIF(survivor.text, '') IS NULL
+			AND donor.text IS NOT NULL AND donor.text <> ''
+		ORDER BY array_position($2::bigint[], donor.song_id::bigint)
+		LIMIT 1`
+
+	var donorID int64
+	if err := tx.QueryRowContext(ctx, query, survivorID, duplicateIDs).Scan(&donorID); err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return donorID, nil
+}
+
+// mergeSongDetails fills the empty details of the survivor with the first non-empty details of the duplicates
+func mergeSongDetails(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	query := `
+		UPDATE song_details SET
+			release_date = COALESCE(song_details.release_date, merged.release_date),
+			text = COALESCE(NULLIF(song_details.text, ''), merged.text)
+		FROM (
+			SELECT
+				(array_agg(release_date ORDER BY array_position($2::bigint[], song_id::bigint))
+					FILTER (WHERE release_date IS NOT NULL))[1] AS release_date,
+				(array_agg(text ORDER BY array_position($2::bigint[], song_id::bigint))
+					FILTER (WHERE text IS NOT NULL AND text <> ''))[1] AS text
+			FROM song_details
+			WHERE song_id = ANY($2)
+		) AS merged
+		WHERE song_details.song_id = $1
+			AND ((song_details.release_date IS NULL AND merged.release_date IS NOT NULL)
+				OR (NULLIF(song_details.text, '') IS NULL AND merged.text IS NOT NULL))`
+
+	_, err := tx.ExecContext(ctx, query, survivorID, duplicateIDs)
+	return err
+}
+
+// mergeSongLinks copies the links of the duplicates to the survivor, the survivor without the primary link
+// gets the primary link of the first duplicate which has it
+func mergeSongLinks(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	query := `
+		INSERT INTO song_links (song_id, platform, url, is_primary, created_at)
+		SELECT $1::bigint, platform, url, false, created_at
+		FROM song_links
+		WHERE song_id = ANY($2)
+		ORDER BY array_position($2::bigint[], song_id), id
+		ON CONFLICT (song_id, url) DO NOTHING`
+
+	if _, err := tx.ExecContext(ctx, query, survivorID, duplicateIDs); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE song_links SET is_primary = true
+		WHERE song_id = $1
+			AND url = (
+				SELECT url FROM song_links
+				WHERE song_id = ANY($2) AND is_primary
+				ORDER BY array_position($2::bigint[], song_id)
+				LIMIT 1
+			)
+			AND NOT EXISTS (SELECT 1 FROM song_links WHERE song_id = $1 AND is_primary)`
+
+	_, err := tx.ExecContext(ctx, query, survivorID, duplicateIDs)
+	return err
+}
+
+// mergeAlbumTracks moves the album tracks of the duplicates to the survivor. If several merged songs
+// are the tracks of the same album, the album keeps the track with the smallest position
+func mergeAlbumTracks(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	songIDs := append([]int64{survivorID}, duplicateIDs...)
+
+	query := `
+		DELETE FROM album_tracks AS tracks
+		WHERE tracks.song_id = ANY($1) AND EXISTS (
+			SELECT 1 FROM album_tracks AS other
+			WHERE other.album_id = tracks.album_id
+				AND other.song_id = ANY($1)
+				AND other.position < tracks.position
+		)`
+
+	if _, err := tx.ExecContext(ctx, query, songIDs); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "UPDATE album_tracks SET song_id = $1 WHERE song_id = ANY($2)", survivorID, duplicateIDs)
+	return err
+}
+
+// mergeLyricsTranslations copies the translations of the duplicates to the survivor. The survivor keeps its own
+// translations, the missing language is taken from the first duplicate which has it
+func mergeLyricsTranslations(ctx context.Context, tx dbContext, survivorID int64, duplicateIDs []int64) error {
+	query := `
+		INSERT INTO lyrics_translations (song_id, lang, text, author, created_at, updated_at)
+		SELECT DISTINCT ON (lang) $1::bigint, lang, text, author, created_at, updated_at
+		FROM lyrics_translations
+		WHERE song_id = ANY($2)
+		ORDER BY lang, array_position($2::bigint[], song_id)
+		ON CONFLICT (song_id, lang) DO NOTHING`
+
+	_, err := tx.ExecContext(ctx, query, survivorID, duplicateIDs)
+	return err
+}
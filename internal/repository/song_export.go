@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/filter"
+)
+
+// exportFetchSize is the number of the songs fetched from the export cursor at once
+const exportFetchSize = 500
+
+// ExportSongs passes every song matching the filter to the fn in the order of the ids. The songs are read
+// through the server-side cursor by small portions, so the memory doesn't depend on the size of the library.
+// The export stops on the first error of the fn
+func (r *Song) ExportSongs(ctx context.Context, aggregation map[string]any, fn func(song *dto.SongWithDetails) error) error {
+	slog.Debug("export songs", "aggregation data=", aggregation)
+
+	filterNode, _ := aggregation["filter"].(filter.Node)
+	fields, _ := aggregation["fields"].(string)
+
+	whereExpr, err := buildGetSongsWhereExpr(filterNode)
+	if err != nil {
+		return err
+	}
+
+	queryBuilder := selectSongs(buildGetSongsColumnNames(fields)...).
+		Where(whereExpr).
+		OrderBy("songs.id")
+
+	if similarity := buildSimilarityExpr(filterNode); similarity != nil {
+		queryBuilder = queryBuilder.Column(squirrel.Alias(similarity, "similarity"))
+	}
+
+	query, args := queryBuilder.MustSql()
+
+	//the cursor lives until the end of the transaction
+	return execTx(ctx, r.db, "song.ExportSongs", func(tx dbContext) error {
+		if _, err := tx.ExecContext(ctx, "DECLARE songs_export NO SCROLL CURSOR FOR "+query, args...); err != nil {
+			return wrapQueryExecError("song.ExportSongs", err)
+		}
+
+		fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM songs_export", exportFetchSize)
+		for {
+			songs := make([]*dto.SongWithDetails, 0, exportFetchSize)
+			if err := tx.SelectContext(ctx, &songs, fetchQuery); err != nil {
+				return wrapQueryExecError("song.ExportSongs", err)
+			}
+
+			for _, song := range songs {
+				if err := fn(song); err != nil {
+					return err
+				}
+			}
+
+			if len(songs) < exportFetchSize {
+				return nil
+			}
+		}
+	})
+}
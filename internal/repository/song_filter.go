@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/filter"
+)
+
+// songsFilterColumns maps the filter fields to the compared columns, the fields
+// which are matched by the subqueries [album, tags, link] have their own builders
+var songsFilterColumns = map[string]string{
+	"song_id":      "songs.id",
+	"artist_id":    "songs.artist_id",
+	"group":        "artists.name",
+	"song":         "songs.song_name",
+	"release_date": "song_details.release_date",
+	"text":         "song_details.text",
+}
+
+// buildGetSongsWhereExpr compiles the filter tree into the where expr of the songs list.
+// Returns nil if the filter is empty
+func buildGetSongsWhereExpr(node filter.Node) (squirrel.Sqlizer, error) {
+	switch node := node.(type) {
+	case nil:
+		return nil, nil
+
+	case *filter.And:
+		and := squirrel.And{}
+		for _, child := range node.Nodes {
+			condition, err := buildGetSongsWhereExpr(child)
+			if err != nil {
+				return nil, err
+			}
+			and = append(and, condition)
+		}
+		return and, nil
+
+	case *filter.Or:
+		or := squirrel.Or{}
+		for _, child := range node.Nodes {
+			condition, err := buildGetSongsWhereExpr(child)
+			if err != nil {
+				return nil, err
+			}
+			or = append(or, condition)
+		}
+		return or, nil
+
+	case *filter.Not:
+		condition, err := buildGetSongsWhereExpr(node.Node)
+		if err != nil {
+			return nil, err
+		}
+		//the condition on the NULL value is unknown, NOT keeps it unknown, so such rows are matched explicitly
+		return squirrel.Expr("(?) IS NOT TRUE", condition), nil
+
+	case *filter.Comparison:
+		return buildSongsComparison(node)
+
+	default:
+		debugMessage := fmt.Sprintf("unsupported filter node: %T", node)
+		return nil, dto.NewError(500, "internal server error", "buildGetSongsWhereExpr", nil, debugMessage)
+	}
+}
+
+// buildSongsComparison builds the condition of the single comparison of the songs filter
+func buildSongsComparison(c *filter.Comparison) (squirrel.Sqlizer, error) {
+	switch c.Field {
+	case "album":
+		return buildAlbumCondition(c), nil
+	case "tags":
+		return buildTagsCondition(c), nil
+	case "link":
+		return buildLinkCondition(c), nil
+	}
+
+	column, ok := songsFilterColumns[c.Field]
+	if !ok {
+		return nil, dto.NewError(400, "unknown filter field", "buildSongsComparison", c.Field, nil)
+	}
+	return buildColumnComparison(column, c)
+}
+
+// buildColumnComparison compares the column with the values of the comparison.
+// The "ne" and "out" ops treat NULL as a distinct value, so the songs without the value are matched by them
+func buildColumnComparison(column string, c *filter.Comparison) (squirrel.Sqlizer, error) {
+	switch c.Op {
+	case filter.OpEq:
+		return squirrel.Expr(column+" = ?", c.Values[0]), nil
+	case filter.OpNe:
+		return squirrel.Expr(column+" IS DISTINCT FROM ?", c.Values[0]), nil
+	case filter.OpLt:
+		return squirrel.Expr(column+" < ?", c.Values[0]), nil
+	case filter.OpLe:
+		return squirrel.Expr(column+" <= ?", c.Values[0]), nil
+	case filter.OpGt:
+		return squirrel.Expr(column+" > ?", c.Values[0]), nil
+	case filter.OpGe:
+		return squirrel.Expr(column+" >= ?", c.Values[0]), nil
+	case filter.OpIn:
+		return squirrel.Eq{column: c.Values}, nil
+	case filter.OpOut:
+		return squirrel.Expr("(?) IS NOT TRUE", squirrel.Eq{column: c.Values}), nil
+	case filter.OpLike:
+		return squirrel.ILike{column: buildLikePattern(c.Values[0])}, nil
+	case filter.OpFuzzy:
+		return squirrel.Expr(column+" % ?", c.Values[0]), nil
+	case filter.OpNull:
+		if isNull, _ := c.Values[0].(bool); isNull {
+			return squirrel.Expr(column + " IS NULL"), nil
+		}
+		return squirrel.Expr(column + " IS NOT NULL"), nil
+	default:
+		details := fmt.Sprintf("field=%s, op=%s", c.Field, c.Op)
+		return nil, dto.NewError(400, "unsupported filter operator", "buildColumnComparison", details, nil)
+	}
+}
+
+// buildLikePattern converts the filter pattern to the LIKE pattern, "*" matches any characters
+// and the LIKE wildcards of the value are matched literally [e.g. *100%* -> %100\%%]
+func buildLikePattern(value any) string {
+	pattern, _ := value.(string)
+	return strings.ReplaceAll(escapeLikePattern(pattern), "*", "%")
+}
+
+// buildSimilarityExpr builds the expression of the best similarity of the song to the terms
+// of the fuzzy comparisons [e.g. GREATEST(similarity(artists.name, ?))]. Returns nil if the
+// filter hasn't got fuzzy comparisons
+func buildSimilarityExpr(node filter.Node) squirrel.Sqlizer {
+	expressions := make([]string, 0)
+	args := make([]any, 0)
+
+	filter.Walk(node, func(c *filter.Comparison) {
+		column, ok := songsFilterColumns[c.Field]
+		if c.Op != filter.OpFuzzy || !ok {
+			return
+		}
+		expressions = append(expressions, fmt.Sprintf("similarity(%s, ?)", column))
+		args = append(args, c.Values[0])
+	})
+
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	expr := fmt.Sprintf("GREATEST(%s)", strings.Join(expressions, ", "))
+	return squirrel.Expr(expr, args...)
+}
+
+// buildAlbumCondition builds a constraint which matches the tracks of the albums
+// [e.g. songs.id IN (SELECT song_id FROM album_tracks WHERE album_id IN (1, 2))]
+func buildAlbumCondition(c *filter.Comparison) squirrel.Sqlizer {
+	subquery := squirrel.Expr("songs.id IN (SELECT song_id FROM album_tracks WHERE ?)", squirrel.Eq{"album_id": c.Values})
+	if c.Op == filter.OpOut {
+		return squirrel.Expr("NOT (?)", subquery)
+	}
+	return subquery
+}
+
+// buildTagsCondition builds a constraint which matches the songs by their tags, the tag names are case insensitive.
+// The "eq" and "in" ops match the songs with at least one of the tags, "all" matches the songs with every tag
+// and "out" matches the songs without any of the tags
+func buildTagsCondition(c *filter.Comparison) squirrel.Sqlizer {
+	tags := make([]string, 0, len(c.Values))
+	for _, value := range c.Values {
+		tag, _ := value.(string)
+		tags = append(tags, strings.ToLower(tag))
+	}
+
+	switch c.Op {
+	case filter.OpAll:
+		return squirrel.Expr(`songs.id IN (
+			SELECT song_tags.song_id FROM song_tags
+			JOIN tags ON tags.id = song_tags.tag_id
+			WHERE tags.name = ANY(?)
+			GROUP BY song_tags.song_id
+			HAVING count(DISTINCT tags.id) = ?)`, tags, uniqueCount(tags))
+
+	case filter.OpOut:
+		return squirrel.Expr(`songs.id NOT IN (
+			SELECT song_tags.song_id FROM song_tags
+			JOIN tags ON tags.id = song_tags.tag_id
+			WHERE tags.name = ANY(?))`, tags)
+
+	default:
+		return squirrel.Expr(`songs.id IN (
+			SELECT song_tags.song_id FROM song_tags
+			JOIN tags ON tags.id = song_tags.tag_id
+			WHERE tags.name = ANY(?))`, tags)
+	}
+}
+
+// uniqueCount returns the number of unique values
+func uniqueCount(values []string) int {
+	unique := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		unique[value] = struct{}{}
+	}
+	return len(unique)
+}
+
+// buildLinkCondition builds a constraint which matches the songs by any of their links.
+// The "null" op matches the songs without links [link=null=true] or with them [link=null=false]
+func buildLinkCondition(c *filter.Comparison) squirrel.Sqlizer {
+	const hasLinks = "EXISTS (SELECT 1 FROM song_links WHERE song_links.song_id = songs.id)"
+
+	switch c.Op {
+	case filter.OpNull:
+		if isNull, _ := c.Values[0].(bool); isNull {
+			return squirrel.Expr("NOT " + hasLinks)
+		}
+		return squirrel.Expr(hasLinks)
+
+	case filter.OpLike:
+		return squirrel.Expr(
+			"EXISTS (SELECT 1 FROM song_links WHERE song_links.song_id = songs.id AND song_links.url ILIKE ?)",
+			buildLikePattern(c.Values[0]),
+		)
+
+	default:
+		return squirrel.Expr(
+			"EXISTS (SELECT 1 FROM song_links WHERE song_links.song_id = songs.id AND song_links.url = ?)",
+			c.Values[0],
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// errImportDryRun rolls back the transaction of the dry run import
+var errImportDryRun = errors.New("dry run")
+
+// ImportSongs saves the batch of the imported songs with their details in one transaction. The songs which
+// are already in the library are skipped, the failed song doesn't break the batch: its changes are rolled back
+// to the savepoint. In the dry run the whole transaction is rolled back, so the report is exact but nothing is saved.
+// The transaction of the context isn't rolled back by the dry run, its owner must roll it back
+func (r *Song) ImportSongs(ctx context.Context, songs []*model.ImportSong, dryRun bool) ([]*dto.ImportSongResult, error) {
+	slog.Debug("import songs", "count", len(songs), "dry_run", dryRun)
+
+	results := make([]*dto.ImportSongResult, 0, len(songs))
+	err := execTx(ctx, r.db, "song.ImportSongs", func(tx dbContext) error {
+		for _, song := range songs {
+			result, err := importSong(ctx, tx, song)
+			if err != nil {
+				return err
+			}
+
+			//the ids of the dry run songs don't exist after the rollback
+			if dryRun && result.Status == model.ImportCreated {
+				result.SongID = nil
+			}
+			results = append(results, result)
+		}
+
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// importSong saves the song in the savepoint. The error is only returned if the savepoint can't be
+// rolled back, the errors of the song itself are reported in the result
+func importSong(ctx context.Context, tx dbContext, song *model.ImportSong) (*dto.ImportSongResult, error) {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT import_song"); err != nil {
+		return nil, wrapQueryExecError("song.ImportSongs", err)
+	}
+
+	result, err := saveImportSong(ctx, tx, song)
+	if err != nil {
+		slog.Warn("failed to import the song", "row", song.Row, "err", err)
+
+		if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_song"); err != nil {
+			return nil, wrapQueryExecError("song.ImportSongs", err)
+		}
+		return &dto.ImportSongResult{Row: song.Row, Status: model.ImportFailed, Reason: "failed to save the song"}, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT import_song"); err != nil {
+		return nil, wrapQueryExecError("song.ImportSongs", err)
+	}
+
+	return result, nil
+}
+
+func saveImportSong(ctx context.Context, tx dbContext, song *model.ImportSong) (*dto.ImportSongResult, error) {
+	if err := tx.QueryRowContext(ctx, upsertArtistQuery, song.Song.Group).Scan(&song.Song.ArtistID); err != nil {
+		return nil, err
+	}
+
+	var songIDs []int64
+	query := `
+		SELECT id FROM songs
+		WHERE artist_id = $1 AND song_name_normalize(song_name) = song_name_normalize($2)
+		ORDER BY legacy_duplicate, id
+		LIMIT 1`
+	if err := tx.SelectContext(ctx, &songIDs, query, song.Song.ArtistID, song.Song.Name); err != nil {
+		return nil, err
+	}
+
+	if len(songIDs) != 0 {
+		return &dto.ImportSongResult{Row: song.Row, Status: model.ImportSkipped, SongID: &songIDs[0], Reason: "song already exists"}, nil
+	}
+
+	query = "INSERT INTO songs (artist_id, song_name) VALUES ($1, $2) RETURNING id"
+	if err := tx.QueryRowContext(ctx, query, song.Song.ArtistID, song.Song.Name).Scan(&song.Song.ID); err != nil {
+		return nil, err
+	}
+
+	setMap := map[string]any{
+		"text":         song.Details.Text,
+		"release_date": song.Details.ReleaseDate,
+	}
+
+	if _, err := updateRowContext(ctx, tx, "song_details", squirrel.Eq{"song_id": song.Song.ID}, setMap); err != nil {
+		return nil, err
+	}
+
+	if song.Details.Link != nil {
+		if err := setPrimaryLink(ctx, tx, song.Song.ID, *song.Details.Link, "song.ImportSongs"); err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.ImportSongResult{Row: song.Row, Status: model.ImportCreated, SongID: &song.Song.ID}, nil
+}
@@ -0,0 +1,280 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// songsSortColumns maps the sort fields of the songs list to the columns
+var songsSortColumns = map[string]string{
+	"song_id":      "songs.id",
+	"group":        "artists.name",
+	"song":         "songs.song_name",
+	"release_date": "song_details.release_date",
+}
+
+// songsSortKey describes one key of the songs list order
+type songsSortKey struct {
+	model.SortField
+	// expr is the value of the key, it's compared with the cursor values
+	expr squirrel.Sqlizer
+	// column is the ORDER BY column of the key
+	column string
+}
+
+// songsPageRow is the song of the list with the values of its sort keys
+type songsPageRow struct {
+	dto.SongWithDetails
+	CursorKey json.RawMessage `db:"cursor_key"`
+}
+
+// songsCursor is the position in the songs list: the sort key values of the last song of the page
+type songsCursor struct {
+	// Sort is the signature of the list order, the cursor is only valid for the same order
+	Sort   string          `json:"s"`
+	Values json.RawMessage `json:"v"`
+}
+
+// selectSongs starts the select query of the songs with their artists and details
+func selectSongs(columns ...string) squirrel.SelectBuilder {
+	return squirrel.
+		Select(columns...).
+		From("songs").
+		Join("artists ON artists.id = songs.artist_id").
+		Join("song_details ON songs.id = song_details.song_id").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+// buildGetSongsSortKeys builds the keys of the songs list order. The songs are ordered by the similarity
+// if the fuzzy filter is used, by the id otherwise. The id is always the last key, so the songs with
+// the same values of the sort fields keep the stable order and the cursor points to the exact position
+func buildGetSongsSortKeys(sort []model.SortField, similarity squirrel.Sqlizer) ([]songsSortKey, error) {
+	if len(sort) == 0 && similarity != nil {
+		sort = []model.SortField{{Field: "similarity", Desc: true}}
+	}
+
+	keys := make([]songsSortKey, 0, len(sort)+1)
+	sortedByID := false
+
+	for _, field := range sort {
+		key := songsSortKey{SortField: field}
+
+		if field.Field == "similarity" {
+			if similarity == nil {
+				return nil, dto.NewError(400, "invalid sort field", "buildGetSongsSortKeys", field.Field, nil)
+			}
+			key.expr, key.column = similarity, "similarity"
+
+		} else {
+			column, ok := songsSortColumns[field.Field]
+			if !ok {
+				return nil, dto.NewError(400, "invalid sort field", "buildGetSongsSortKeys", field.Field, nil)
+			}
+			key.expr, key.column = squirrel.Expr(column), column
+		}
+
+		keys = append(keys, key)
+		sortedByID = sortedByID || field.Field == "song_id"
+	}
+
+	if !sortedByID {
+		keys = append(keys, songsSortKey{
+			SortField: model.SortField{Field: "song_id"},
+			expr:      squirrel.Expr("songs.id"),
+			column:    "songs.id",
+		})
+	}
+
+	return keys, nil
+}
+
+func sortKeysOrderBy(keys []songsSortKey) []string {
+	orderBy := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction, nulls := "ASC", "NULLS LAST"
+		if key.Desc {
+			direction = "DESC"
+		}
+		if key.NullsFirst {
+			nulls = "NULLS FIRST"
+		}
+		orderBy = append(orderBy, fmt.Sprintf("%s %s %s", key.column, direction, nulls))
+	}
+	return orderBy
+}
+
+// buildCursorKeyColumn builds the select expression of the sort key values of the song [e.g. json_build_array(artists.name, songs.id)]
+func buildCursorKeyColumn(keys []songsSortKey) squirrel.Sqlizer {
+	placeholders := make([]string, 0, len(keys))
+	exprs := make([]any, 0, len(keys))
+
+	for _, key := range keys {
+		placeholders = append(placeholders, "?")
+		exprs = append(exprs, key.expr)
+	}
+
+	return squirrel.Expr(fmt.Sprintf("json_build_array(%s) AS cursor_key", strings.Join(placeholders, ", ")), exprs...)
+}
+
+// sortSignature describes the order of the keys [e.g. -release_date:nulls_first,group,song_id]
+func sortSignature(keys []songsSortKey) string {
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		field := key.Field
+		if key.Desc {
+			field = "-" + field
+		}
+		if key.NullsFirst {
+			field += ":nulls_first"
+		}
+		fields = append(fields, field)
+	}
+	return strings.Join(fields, ",")
+}
+
+// encodeSongsCursor encodes the sort key values of the song into the opaque cursor
+func encodeSongsCursor(keys []songsSortKey, values json.RawMessage) string {
+	cursor, _ := json.Marshal(songsCursor{Sort: sortSignature(keys), Values: values})
+	return base64.RawURLEncoding.EncodeToString(cursor)
+}
+
+// decodeSongsCursor decodes the sort key values of the cursor, the values are returned in the text
+// form (nil for NULL), so the database converts them to the types of the keys
+func decodeSongsCursor(rawCursor string, keys []songsSortKey) ([]any, error) {
+	invalidCursorError := dto.NewError(400, "invalid cursor", "decodeSongsCursor", rawCursor, nil)
+
+	data, err := base64.RawURLEncoding.DecodeString(rawCursor)
+	if err != nil {
+		return nil, invalidCursorError
+	}
+
+	var cursor songsCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, invalidCursorError
+	}
+
+	if cursor.Sort != sortSignature(keys) {
+		details := fmt.Sprintf("cursor sort=%s, but the request sort=%s", cursor.Sort, sortSignature(keys))
+		return nil, dto.NewError(400, "cursor doesn't match the sort param", "decodeSongsCursor", details, nil)
+	}
+
+	var rawValues []any
+	decoder := json.NewDecoder(bytes.NewReader(cursor.Values))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&rawValues); err != nil || len(rawValues) != len(keys) {
+		return nil, invalidCursorError
+	}
+
+	values := make([]any, 0, len(rawValues))
+	for _, value := range rawValues {
+		switch value := value.(type) {
+		case nil:
+			values = append(values, nil)
+		case string:
+			values = append(values, value)
+		case json.Number:
+			values = append(values, value.String())
+		default:
+			return nil, invalidCursorError
+		}
+	}
+
+	return values, nil
+}
+
+// buildCursorCondition builds the constraint which matches the songs after the cursor position.
+// For the keys k1, k2 with the cursor values v1, v2 the condition is [k1 after v1 OR (k1 = v1 AND k2 after v2)]
+func buildCursorCondition(keys []songsSortKey, values []any) squirrel.Sqlizer {
+	or := squirrel.Or{}
+	equals := squirrel.And{}
+
+	for idx, key := range keys {
+		if after := buildKeyAfterCondition(key, values[idx]); after != nil {
+			or = append(or, append(append(squirrel.And{}, equals...), after))
+		}
+
+		if values[idx] == nil {
+			equals = append(equals, squirrel.Expr("? IS NULL", key.expr))
+		} else {
+			equals = append(equals, squirrel.Expr("? = ?", key.expr, values[idx]))
+		}
+	}
+
+	if len(or) == 0 {
+		return squirrel.Expr("false")
+	}
+	return or
+}
+
+// buildKeyAfterCondition matches the key values which follow the value in the order of the key.
+// Returns nil if no value can follow the value [e.g. NULL is the last value of the NULLS LAST order]
+func buildKeyAfterCondition(key songsSortKey, value any) squirrel.Sqlizer {
+	if value == nil {
+		if key.NullsFirst {
+			return squirrel.Expr("? IS NOT NULL", key.expr)
+		}
+		return nil
+	}
+
+	comparison := ">"
+	if key.Desc {
+		comparison = "<"
+	}
+
+	after := squirrel.Expr(fmt.Sprintf("? %s ?", comparison), key.expr, value)
+	if key.NullsFirst {
+		return after
+	}
+	return squirrel.Or{after, squirrel.Expr("? IS NULL", key.expr)}
+}
+
+// countSongs sets the total number of songs matching the where expr to the response. The total
+// takes one value from [exact, approx, none], the approximate number is estimated by the planner
+func (r *Song) countSongs(ctx context.Context, whereExpr squirrel.Sqlizer, total string, response *dto.GetSongsResponse) error {
+	switch total {
+	case "none":
+		return nil
+
+	case "approx":
+		query, args := selectSongs("1").Where(whereExpr).MustSql()
+
+		var plan []struct {
+			Plan struct {
+				Rows float64 `json:"Plan Rows"`
+			} `json:"Plan"`
+		}
+
+		var rawPlan []byte
+		if err := executor(ctx, r.db).QueryRowContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...).Scan(&rawPlan); err != nil {
+			return wrapQueryExecError("song.countSongs", err)
+		}
+
+		if err := json.Unmarshal(rawPlan, &plan); err != nil || len(plan) == 0 {
+			return dto.NewError(500, "internal server error", "song.countSongs", nil, fmt.Sprintf("failed to parse the plan: %v", err))
+		}
+
+		count := int64(plan[0].Plan.Rows)
+		response.Total, response.TotalApprox = &count, true
+		return nil
+
+	default:
+		query, args := selectSongs("count(*)").Where(whereExpr).MustSql()
+
+		var count int64
+		if err := executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+			return wrapQueryExecError("song.countSongs", err)
+		}
+
+		response.Total = &count
+		return nil
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+)
+
+// SetChangeAuthor sets the author and the reason of the changes of the lyrics made by the transaction of the context.
+// The revisions are recorded by the trigger, it reads them from the settings which live until the end of the transaction
+func (r *Song) SetChangeAuthor(ctx context.Context, author string, reason string) error {
+	slog.Debug("set change author", "author", author, "reason", reason)
+
+	query := "SELECT set_config('music_library.revision_author', $1, true), set_config('music_library.revision_reason', $2, true)"
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, author, reason); err != nil {
+		return wrapQueryExecError("song.SetChangeAuthor", err)
+	}
+
+	return nil
+}
+
+// GetLyricsRevisions returns the page of the revisions of the lyrics without their texts, the newest revision goes first
+func (r *Song) GetLyricsRevisions(ctx context.Context, songID int64, limit int64, offset int64) ([]*dto.LyricsRevision, error) {
+	slog.Debug("get lyrics revisions", "song_id", songID, "limit", limit, "offset", offset)
+
+	query, args := squirrel.
+		Select("revision", "author", "reason", "created_at").
+		From("lyrics_revisions").
+		Where(squirrel.Eq{"song_id": songID}).
+		OrderBy("revision DESC").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	revisions := make([]*dto.LyricsRevision, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &revisions, query, args...); err != nil {
+		return nil, wrapQueryExecError("song.GetLyricsRevisions", err)
+	}
+
+	//an empty page is ambiguous: the song can have no lyrics or not exist at all
+	if len(revisions) == 0 {
+		var exists bool
+		if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1)", songID).Scan(&exists); err != nil {
+			return nil, wrapQueryExecError("song.GetLyricsRevisions", err)
+		}
+
+		if !exists {
+			details := fmt.Sprintf("id=%d", songID)
+			return nil, dto.NewNotFoundError("song not found", "song.GetLyricsRevisions", details)
+		}
+	}
+
+	return revisions, nil
+}
+
+// GetLyricsRevision returns the revision of the lyrics with its text
+func (r *Song) GetLyricsRevision(ctx context.Context, songID int64, revision int64) (*dto.LyricsRevision, error) {
+	slog.Debug("get lyrics revision", "song_id", songID, "revision", revision)
+
+	query, args := squirrel.
+		Select("revision", "author", "reason", "created_at", "text").
+		From("lyrics_revisions").
+		Where(squirrel.Eq{"song_id": songID, "revision": revision}).
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	var lyricsRevision dto.LyricsRevision
+	if err := executor(ctx, r.db).GetContext(ctx, &lyricsRevision, query, args...); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("song_id=%d, revision=%d", songID, revision)
+			return nil, dto.NewNotFoundError("lyrics revision not found", "song.GetLyricsRevision", details)
+		}
+
+		return nil, wrapQueryExecError("song.GetLyricsRevision", err)
+	}
+
+	return &lyricsRevision, nil
+}
+
+// GetLatestLyricsRevision returns the newest revision of the lyrics without its text
+func (r *Song) GetLatestLyricsRevision(ctx context.Context, songID int64) (*dto.LyricsRevision, error) {
+	revisions, err := r.GetLyricsRevisions(ctx, songID, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		details := fmt.Sprintf("song_id=%d", songID)
+		return nil, dto.NewNotFoundError("lyrics revision not found", "song.GetLatestLyricsRevision", details)
+	}
+
+	return revisions[0], nil
+}
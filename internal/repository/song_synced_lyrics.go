@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// SetSyncedLyrics replaces the timed lines of the lyrics of the song, the lines must be ordered by their time.
+// The timing is kept while the text of the song is the lines joined by the new lines,
+// so the text must be updated in the same transaction
+func (r *Song) SetSyncedLyrics(ctx context.Context, songID int64, lines []*model.SyncedLyricsLine) error {
+	slog.Debug("set synced lyrics", "song_id", songID, "lines", len(lines))
+
+	return execTx(ctx, r.db, "song.SetSyncedLyrics", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, songID, "song.SetSyncedLyrics"); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM synced_lyrics_lines WHERE song_id = $1", songID); err != nil {
+			return wrapQueryExecError("song.SetSyncedLyrics", err)
+		}
+
+		for start := 0; start < len(lines); start += syncedLyricsInsertRows {
+			end := min(start+syncedLyricsInsertRows, len(lines))
+			if err := insertSyncedLyricsLines(ctx, tx, songID, start, lines[start:end]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// syncedLyricsInsertRows is the number of the lines inserted by one statement,
+// the statement must stay below the limit of 65535 bind parameters
+const syncedLyricsInsertRows = 1000
+
+// insertSyncedLyricsLines inserts the lines of the lyrics, the first line has the number offset+1
+func insertSyncedLyricsLines(ctx context.Context, tx dbContext, songID int64, offset int, lines []*model.SyncedLyricsLine) error {
+	queryBuilder := squirrel.
+		Insert("synced_lyrics_lines").
+		Columns("song_id", "line_no", "start_ms", "text", "words").
+		PlaceholderFormat(squirrel.Dollar)
+
+	for idx, line := range lines {
+		words, err := syncedLyricsWordsValue(line.Words)
+		if err != nil {
+			return dto.NewError(500, "internal server error", "song.SetSyncedLyrics", nil, err.Error())
+		}
+		queryBuilder = queryBuilder.Values(songID, offset+idx+1, line.StartMs, line.Text, words)
+	}
+
+	query, args := queryBuilder.MustSql()
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return wrapQueryExecError("song.SetSyncedLyrics", err)
+	}
+
+	return nil
+}
+
+// syncedLyricsWordsValue returns the json of the words, nil if the line has no words
+func syncedLyricsWordsValue(words []model.SyncedLyricsWord) (any, error) {
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	values := make([]dto.SyncedLyricsWord, 0, len(words))
+	for _, word := range words {
+		values = append(values, dto.SyncedLyricsWord{StartMs: word.StartMs, Text: word.Text})
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// GetSyncedLyrics returns the timed lines of the lyrics ordered by their time, the empty list if the song has no timing
+func (r *Song) GetSyncedLyrics(ctx context.Context, songID int64) ([]*dto.SyncedLyricsLine, error) {
+	slog.Debug("get synced lyrics", "song_id", songID)
+
+	query, args := squirrel.
+		Select("line_no", "start_ms", "lead(start_ms) OVER (ORDER BY line_no) AS end_ms", "text", "words").
+		From("synced_lyrics_lines").
+		Where(squirrel.Eq{"song_id": songID}).
+		OrderBy("line_no").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	lines := make([]*dto.SyncedLyricsLine, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &lines, query, args...); err != nil {
+		return nil, wrapQueryExecError("song.GetSyncedLyrics", err)
+	}
+
+	//an empty list is ambiguous: the song can have no timing or not exist at all
+	if len(lines) == 0 {
+		var exists bool
+		if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1)", songID).Scan(&exists); err != nil {
+			return nil, wrapQueryExecError("song.GetSyncedLyrics", err)
+		}
+
+		if !exists {
+			details := fmt.Sprintf("id=%d", songID)
+			return nil, dto.NewNotFoundError("song not found", "song.GetSyncedLyrics", details)
+		}
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/amicie-monami/music-library/internal/domain/model"
+)
+
+// GetTranslations returns the translations of the lyrics of the song ordered by the language
+func (r *Song) GetTranslations(ctx context.Context, songID int64) ([]*dto.LyricsTranslation, error) {
+	slog.Debug("get lyrics translations", "song_id", songID)
+
+	query, args := squirrel.
+		Select("lang", "text", "author", "created_at", "updated_at").
+		From("lyrics_translations").
+		Where(squirrel.Eq{"song_id": songID}).
+		OrderBy("lang").
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	translations := make([]*dto.LyricsTranslation, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &translations, query, args...); err != nil {
+		return nil, wrapQueryExecError("song.GetTranslations", err)
+	}
+
+	//an empty list is ambiguous: the song can have no translations or not exist at all
+	if len(translations) == 0 {
+		var exists bool
+		if err := executor(ctx, r.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM songs WHERE id = $1)", songID).Scan(&exists); err != nil {
+			return nil, wrapQueryExecError("song.GetTranslations", err)
+		}
+
+		if !exists {
+			details := fmt.Sprintf("id=%d", songID)
+			return nil, dto.NewNotFoundError("song not found", "song.GetTranslations", details)
+		}
+	}
+
+	return translations, nil
+}
+
+// GetTranslation returns the translation of the lyrics of the song to the language
+func (r *Song) GetTranslation(ctx context.Context, songID int64, lang string) (*dto.LyricsTranslation, error) {
+	slog.Debug("get lyrics translation", "song_id", songID, "lang", lang)
+
+	query, args := squirrel.
+		Select("lang", "text", "author", "created_at", "updated_at").
+		From("lyrics_translations").
+		Where(squirrel.Eq{"song_id": songID, "lang": lang}).
+		PlaceholderFormat(squirrel.Dollar).
+		MustSql()
+
+	var translation dto.LyricsTranslation
+	if err := executor(ctx, r.db).GetContext(ctx, &translation, query, args...); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("song_id=%d, lang=%s", songID, lang)
+			return nil, dto.NewNotFoundError("lyrics translation not found", "song.GetTranslation", details)
+		}
+
+		return nil, wrapQueryExecError("song.GetTranslation", err)
+	}
+
+	return &translation, nil
+}
+
+// SaveTranslation creates the translation of the lyrics or replaces the existing translation to the same language.
+// It returns the saved translation and whether it has been created
+func (r *Song) SaveTranslation(ctx context.Context, translation *model.LyricsTranslation) (*dto.LyricsTranslation, bool, error) {
+	slog.Debug("save lyrics translation", "song_id", translation.SongID, "lang", translation.Lang, "author", translation.Author)
+
+	var (
+		saved   dto.LyricsTranslation
+		created bool
+	)
+
+	err := execTx(ctx, r.db, "song.SaveTranslation", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, translation.SongID, "song.SaveTranslation"); err != nil {
+			return err
+		}
+
+		//the row inserted by the statement has no deleting transaction
+		query := `
+			INSERT INTO lyrics_translations (song_id, lang, text, author)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (song_id, lang) DO UPDATE SET
+				text = EXCLUDED.text,
+				author = EXCLUDED.author,
+				updated_at = now()
+			RETURNING lang, text, author, created_at, updated_at, xmax = 0`
+
+		row := tx.QueryRowContext(ctx, query, translation.SongID, translation.Lang, translation.Text, translation.Author)
+		if err := row.Scan(&saved.Lang, &saved.Text, &saved.Author, &saved.CreatedAt, &saved.UpdatedAt, &created); err != nil {
+			return wrapQueryExecError("song.SaveTranslation", err)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return &saved, created, nil
+}
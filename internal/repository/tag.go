@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+)
+
+// Tag object adapter for database operations with the tags and song_tags tables
+type Tag struct {
+	db dbContext
+}
+
+func NewTag(db dbContext) *Tag {
+	return &Tag{db}
+}
+
+/// ------------ Interface ------------ ///
+
+// GetTags returns the page of tags ordered by the number of tagged songs.
+// The kind param is optional and takes one value from [genre, tag]
+func (r *Tag) GetTags(ctx context.Context, kind string, limit int64, offset int64) ([]*dto.Tag, error) {
+	slog.Debug("get tags", "kind", kind, "limit", limit, "offset", offset)
+
+	queryBuilder := squirrel.
+		Select("tags.id", "tags.name", "tags.kind", "count(song_tags.song_id) AS usage_count").
+		From("tags").
+		LeftJoin("song_tags ON song_tags.tag_id = tags.id").
+		GroupBy("tags.id").
+		OrderBy("usage_count DESC", "tags.name").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if kind != "" {
+		queryBuilder = queryBuilder.Where(squirrel.Eq{"tags.kind": kind})
+	}
+
+	query, args := queryBuilder.MustSql()
+
+	tags := make([]*dto.Tag, 0)
+	if err := executor(ctx, r.db).SelectContext(ctx, &tags, query, args...); err != nil {
+		return nil, wrapQueryExecError("tag.GetTags", err)
+	}
+
+	return tags, nil
+}
+
+// AttachTags attaches the tags to the song and returns all tags of the song.
+// Unknown tags are created with the kind, the kind of existing tags isn't changed
+func (r *Tag) AttachTags(ctx context.Context, songID int64, names []string, kind string) ([]*dto.Tag, error) {
+	slog.Debug("attach song tags", "song_id", songID, "tags", names, "kind", kind)
+
+	var tags []*dto.Tag
+	err := execTx(ctx, r.db, "tag.AttachTags", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, songID, "tag.AttachTags"); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO tags (name, kind)
+			SELECT name, $2 FROM unnest($1::TEXT[]) AS name
+			ON CONFLICT (name) DO NOTHING`
+
+		if _, err := tx.ExecContext(ctx, query, names, kind); err != nil {
+			return wrapQueryExecError("tag.AttachTags", err)
+		}
+
+		query = `
+			INSERT INTO song_tags (song_id, tag_id)
+			SELECT $1, id FROM tags WHERE name = ANY($2)
+			ON CONFLICT DO NOTHING`
+
+		if _, err := tx.ExecContext(ctx, query, songID, names); err != nil {
+			return wrapQueryExecError("tag.AttachTags", err)
+		}
+
+		var err error
+		tags, err = getSongTags(ctx, tx, songID)
+		return err
+	})
+
+	return tags, err
+}
+
+// DetachTags detaches the tags from the song and returns the remaining tags of the song
+func (r *Tag) DetachTags(ctx context.Context, songID int64, names []string) ([]*dto.Tag, error) {
+	slog.Debug("detach song tags", "song_id", songID, "tags", names)
+
+	var tags []*dto.Tag
+	err := execTx(ctx, r.db, "tag.DetachTags", func(tx dbContext) error {
+		if err := lockSong(ctx, tx, songID, "tag.DetachTags"); err != nil {
+			return err
+		}
+
+		query := `
+			DELETE FROM song_tags
+			WHERE song_id = $1 AND tag_id IN (SELECT id FROM tags WHERE name = ANY($2))`
+
+		if _, err := tx.ExecContext(ctx, query, songID, names); err != nil {
+			return wrapQueryExecError("tag.DetachTags", err)
+		}
+
+		var err error
+		tags, err = getSongTags(ctx, tx, songID)
+		return err
+	})
+
+	return tags, err
+}
+
+/// ------------ Helpers ------------ ///
+
+// lockSong locks the row of the song until the end of the transaction
+func lockSong(ctx context.Context, tx dbContext, songID int64, source string) error {
+	var id int64
+	if err := tx.QueryRowContext(ctx, "SELECT id FROM songs WHERE id = $1 FOR UPDATE", songID).Scan(&id); err != nil {
+
+		if err == sql.ErrNoRows {
+			details := fmt.Sprintf("id=%d", songID)
+			return dto.NewNotFoundError("song not found", source, details)
+		}
+
+		return wrapQueryExecError(source, err)
+	}
+	return nil
+}
+
+func getSongTags(ctx context.Context, db dbContext, songID int64) ([]*dto.Tag, error) {
+	query := `
+		SELECT tags.id, tags.name, tags.kind
+		FROM song_tags
+		JOIN tags ON tags.id = song_tags.tag_id
+		WHERE song_tags.song_id = $1
+		ORDER BY tags.name`
+
+	tags := make([]*dto.Tag, 0)
+	if err := db.SelectContext(ctx, &tags, query, songID); err != nil {
+		return nil, wrapQueryExecError("tag.getSongTags", err)
+	}
+
+	return tags, nil
+}
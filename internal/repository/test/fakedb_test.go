@@ -0,0 +1,158 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeResponder answers the statement executed by the fake database: the columns and the rows of the query result
+type fakeResponder func(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error)
+
+// fakeDB is the database/sql connector which records the statements and the transaction commands
+// executed on its connections instead of sending them to the database
+type fakeDB struct {
+	mu          sync.Mutex
+	statements  []string
+	connections int
+	respond     fakeResponder
+}
+
+// newFakeDB opens the sqlx database on the fake connector, the nil respond answers all statements with no rows
+func newFakeDB(t *testing.T, respond fakeResponder) (*sqlx.DB, *fakeDB) {
+	fake := &fakeDB{respond: respond}
+	db := sqlx.NewDb(sql.OpenDB(fake), "pgx")
+	t.Cleanup(func() { db.Close() })
+	return db, fake
+}
+
+// Statements returns the executed statements with the collapsed whitespaces
+func (f *fakeDB) Statements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+// Connections returns the number of the opened connections
+func (f *fakeDB) Connections() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.connections
+}
+
+func (f *fakeDB) record(statement string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statements = append(f.statements, strings.Join(strings.Fields(statement), " "))
+}
+
+func (f *fakeDB) execute(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error) {
+	f.record(query)
+	if f.respond == nil {
+		return nil, nil, nil
+	}
+	return f.respond(strings.Join(strings.Fields(query), " "), args)
+}
+
+func (f *fakeDB) Connect(ctx context.Context) (driver.Conn, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.connections++
+	return &fakeConn{db: f}, nil
+}
+
+func (f *fakeDB) Driver() driver.Driver {
+	return fakeDriver{f}
+}
+
+type fakeDriver struct {
+	db *fakeDB
+}
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) {
+	return d.db.Connect(context.Background())
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements aren't supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *fakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.db.record("BEGIN")
+	return fakeTx{c.db}, nil
+}
+
+// CheckNamedValue accepts the arguments of any type, such as the slices passed to ANY($1)
+func (c *fakeConn) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if _, _, err := c.db.execute(query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	columns, rows, err := c.db.execute(query, args)
+	if err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: columns, rows: rows}, nil
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error {
+	tx.db.record("COMMIT")
+	return nil
+}
+
+func (tx fakeTx) Rollback() error {
+	tx.db.record("ROLLBACK")
+	return nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,109 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// statementIndex returns the index of the first statement with the prefix, -1 if there is no such statement
+func statementIndex(statements []string, prefix string) int {
+	return slices.IndexFunc(statements, func(statement string) bool { return strings.HasPrefix(statement, prefix) })
+}
+
+// respondMerge answers the queries of the merge of the songs 1, 2 and 3, donorID is the duplicate
+// which text is taken by the survivor, zero if the survivor keeps its text
+func respondMerge(donorID int64) fakeResponder {
+	return func(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error) {
+		switch {
+		case strings.Contains(query, "AS group_key"):
+			return []string{"id", "group_key", "song_key"}, [][]driver.Value{
+				{int64(1), "muse", "supermassive black hole"},
+				{int64(2), "muse", "supermassive black hole"},
+				{int64(3), "muse", "supermassive black hole"},
+			}, nil
+		case strings.HasPrefix(query, "SELECT donor.song_id"):
+			if donorID == 0 {
+				return []string{"song_id"}, nil, nil
+			}
+			return []string{"song_id"}, [][]driver.Value{{donorID}}, nil
+		case strings.HasPrefix(query, "SELECT"):
+			return []string{"song_id", "group_name", "song_name"}, [][]driver.Value{
+				{int64(1), "Muse", "Supermassive Black Hole"},
+			}, nil
+		}
+		return nil, nil, nil
+	}
+}
+
+func TestMergeSongs(t *testing.T) {
+	db, fake := newFakeDB(t, respondMerge(0))
+
+	song, err := repository.NewSong(db).MergeSongs(context.Background(), 1, []int64{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), song.ID)
+
+	statements := fake.Statements()
+	assert.Equal(t, "BEGIN", statements[0])
+	assert.Equal(t, "COMMIT", statements[len(statements)-1])
+
+	translations := statementIndex(statements, "INSERT INTO lyrics_translations")
+	deletion := statementIndex(statements, "DELETE FROM songs")
+	require.NotEqual(t, -1, translations, "translations of the duplicates aren't copied")
+	assert.Less(t, translations, deletion, "translations are copied after the duplicates are deleted")
+	assert.Contains(t, statements[translations], "ON CONFLICT (song_id, lang) DO NOTHING")
+}
+
+func TestMergeSongsLyrics(t *testing.T) {
+	testCases := []struct {
+		Description string
+		DonorID     int64
+		Moved       bool
+	}{
+		{
+			Description: "Survivor takes the text of the duplicate",
+			DonorID:     3,
+			Moved:       true,
+		},
+		{
+			Description: "Survivor keeps its text",
+			DonorID:     0,
+			Moved:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			db, fake := newFakeDB(t, respondMerge(tc.DonorID))
+
+			_, err := repository.NewSong(db).MergeSongs(context.Background(), 1, []int64{2, 3})
+			require.NoError(t, err)
+
+			statements := fake.Statements()
+			revisions := statementIndex(statements, "UPDATE lyrics_revisions")
+			details := statementIndex(statements, "UPDATE song_details")
+			lines := statementIndex(statements, "UPDATE synced_lyrics_lines")
+			deletion := statementIndex(statements, "DELETE FROM songs")
+
+			if !tc.Moved {
+				assert.Equal(t, -1, revisions)
+				assert.Equal(t, -1, lines)
+				return
+			}
+
+			//the revisions of the duplicate go before the revision of the merge,
+			//the timing is moved after the text, which drops the stale timing of the survivor
+			require.NotEqual(t, -1, revisions)
+			require.NotEqual(t, -1, lines)
+			assert.Less(t, revisions, details)
+			assert.Less(t, details, lines)
+			assert.Less(t, lines, deletion)
+		})
+	}
+}
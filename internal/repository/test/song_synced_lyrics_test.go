@@ -0,0 +1,45 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetSyncedLyricsBatches(t *testing.T) {
+	lineNumbers := make([]int64, 0)
+	argsCounts := make([]int, 0)
+
+	db, _ := newFakeDB(t, func(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error) {
+		switch {
+		case strings.HasPrefix(query, "SELECT id FROM songs"):
+			return []string{"id"}, [][]driver.Value{{int64(1)}}, nil
+		case strings.HasPrefix(query, "INSERT INTO synced_lyrics_lines"):
+			argsCounts = append(argsCounts, len(args))
+			for idx := 1; idx < len(args); idx += 5 {
+				lineNumbers = append(lineNumbers, int64(args[idx].Value.(int)))
+			}
+		}
+		return nil, nil, nil
+	})
+
+	lines := make([]*model.SyncedLyricsLine, 2500)
+	for idx := range lines {
+		lines[idx] = &model.SyncedLyricsLine{StartMs: int64(idx) * 1000, Text: fmt.Sprintf("line %d", idx+1)}
+	}
+
+	require.NoError(t, repository.NewSong(db).SetSyncedLyrics(context.Background(), 1, lines))
+
+	assert.Equal(t, []int{5000, 5000, 2500}, argsCounts)
+	require.Len(t, lineNumbers, len(lines))
+	for idx, number := range lineNumbers {
+		assert.Equal(t, int64(idx+1), number)
+	}
+}
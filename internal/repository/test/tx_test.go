@@ -0,0 +1,190 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/internal/domain/model"
+	"github.com/amicie-monami/music-library/internal/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIsolationLevel(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Level sql.IsolationLevel
+		Valid bool
+	}{
+		{"", sql.LevelDefault, true},
+		{"read_committed", sql.LevelReadCommitted, true},
+		{" Repeatable_Read ", sql.LevelRepeatableRead, true},
+		{"serializable", sql.LevelSerializable, true},
+		{"snapshot", 0, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			level, err := repository.ParseIsolationLevel(tc.Name)
+			if !tc.Valid {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Level, level)
+		})
+	}
+}
+
+// respondArtistID answers the insert of the artist with its id
+func respondArtistID(query string, args []driver.NamedValue) ([]string, [][]driver.Value, error) {
+	if strings.HasPrefix(query, "INSERT INTO artists") {
+		return []string{"id"}, [][]driver.Value{{int64(1)}}, nil
+	}
+	return nil, nil, nil
+}
+
+func TestTxManagerContextExecutor(t *testing.T) {
+	db, fake := newFakeDB(t, respondArtistID)
+	artists := repository.NewArtist(db)
+
+	err := repository.NewTxManager(db, 0).Do(context.Background(), func(ctx context.Context) error {
+		if err := artists.Create(ctx, &model.Artist{Name: "Muse"}); err != nil {
+			return err
+		}
+		//the repository with its own transaction joins the transaction of the context
+		return artists.Rename(ctx, &model.Artist{ID: 1, Name: "MUSE"})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO artists (name) VALUES ($1) RETURNING id",
+		"UPDATE artists SET name = $1 WHERE id = $2",
+		"UPDATE songs SET version = version + 1 WHERE artist_id = $1",
+		"COMMIT",
+	}, fake.Statements())
+	assert.Equal(t, 1, fake.Connections(), "statements of the unit of work are executed outside of the transaction")
+}
+
+func TestTxManagerRollback(t *testing.T) {
+	db, fake := newFakeDB(t, respondArtistID)
+	failure := errors.New("failure")
+
+	err := repository.NewTxManager(db, 0).Do(context.Background(), func(ctx context.Context) error {
+		if err := repository.NewArtist(db).Create(ctx, &model.Artist{Name: "Muse"}); err != nil {
+			return err
+		}
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, []string{"BEGIN", "INSERT INTO artists (name) VALUES ($1) RETURNING id", "ROLLBACK"}, fake.Statements())
+}
+
+func TestTxManagerNestedSavepoints(t *testing.T) {
+	db, fake := newFakeDB(t, respondArtistID)
+	txManager := repository.NewTxManager(db, 0)
+	artists := repository.NewArtist(db)
+	failure := errors.New("failure")
+
+	var nestedErr error
+	err := txManager.Do(context.Background(), func(ctx context.Context) error {
+		if err := artists.Create(ctx, &model.Artist{Name: "Muse"}); err != nil {
+			return err
+		}
+
+		//the failed nested unit of work rolls back only its own changes
+		nestedErr = txManager.Do(ctx, func(ctx context.Context) error {
+			if err := artists.Create(ctx, &model.Artist{Name: "Placebo"}); err != nil {
+				return err
+			}
+			return failure
+		})
+
+		return txManager.Do(ctx, func(ctx context.Context) error {
+			return artists.Create(ctx, &model.Artist{Name: "Radiohead"})
+		})
+	})
+
+	require.NoError(t, err)
+	assert.ErrorIs(t, nestedErr, failure)
+	assert.Equal(t, []string{
+		"BEGIN",
+		"INSERT INTO artists (name) VALUES ($1) RETURNING id",
+		"SAVEPOINT sp_1",
+		"INSERT INTO artists (name) VALUES ($1) RETURNING id",
+		"ROLLBACK TO SAVEPOINT sp_1",
+		"SAVEPOINT sp_2",
+		"INSERT INTO artists (name) VALUES ($1) RETURNING id",
+		"RELEASE SAVEPOINT sp_2",
+		"COMMIT",
+	}, fake.Statements())
+}
+
+func TestTxManagerRetry(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Retries     int
+		Errors      []error
+		Attempts    int
+		Failed      bool
+	}{
+		{
+			Description: "Serialization failure is retried",
+			Retries:     2,
+			Errors:      []error{&pgconn.PgError{Code: "40001"}, nil},
+			Attempts:    2,
+		},
+		{
+			Description: "Deadlock is retried",
+			Retries:     2,
+			Errors:      []error{&pgconn.PgError{Code: "40P01"}, &pgconn.PgError{Code: "40001"}, nil},
+			Attempts:    3,
+		},
+		{
+			Description: "Retries are exhausted",
+			Retries:     1,
+			Errors:      []error{&pgconn.PgError{Code: "40001"}, &pgconn.PgError{Code: "40001"}},
+			Attempts:    2,
+			Failed:      true,
+		},
+		{
+			Description: "Other errors aren't retried",
+			Retries:     2,
+			Errors:      []error{&pgconn.PgError{Code: "23505"}},
+			Attempts:    1,
+			Failed:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			db, fake := newFakeDB(t, nil)
+
+			attempts := 0
+			err := repository.NewTxManager(db, tc.Retries).Do(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return tc.Errors[attempts-1]
+			})
+
+			assert.Equal(t, tc.Attempts, attempts)
+			assert.Equal(t, tc.Failed, err != nil)
+
+			statements := fake.Statements()
+			assert.Len(t, statements, 2*tc.Attempts)
+			for idx := 0; idx < len(statements)-1; idx += 2 {
+				assert.Equal(t, "BEGIN", statements[idx])
+			}
+			if !tc.Failed {
+				assert.Equal(t, "COMMIT", statements[len(statements)-1])
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/amicie-monami/music-library/internal/domain/dto"
+	"github.com/jmoiron/sqlx"
+)
+
+// txRetryDelay is the delay before the first retry of the transaction, it grows with each attempt
+const txRetryDelay = 20 * time.Millisecond
+
+// isolationLevels maps the names of the isolation levels to the levels
+var isolationLevels = map[string]sql.IsolationLevel{
+	"":                sql.LevelDefault,
+	"read_committed":  sql.LevelReadCommitted,
+	"repeatable_read": sql.LevelRepeatableRead,
+	"serializable":    sql.LevelSerializable,
+}
+
+// ParseIsolationLevel parses the name of the isolation level, the empty name is the default level of the database
+func ParseIsolationLevel(name string) (sql.IsolationLevel, error) {
+	level, ok := isolationLevels[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return 0, fmt.Errorf("isolation level=%s, but must be one of [read_committed, repeatable_read, serializable]", name)
+	}
+	return level, nil
+}
+
+// txKey is the key of the transaction in the context
+type txKey struct{}
+
+// txState is the transaction carried by the context and the counter of its savepoints
+type txState struct {
+	tx         *sqlx.Tx
+	savepoints int
+}
+
+func txFromContext(ctx context.Context) *txState {
+	state, _ := ctx.Value(txKey{}).(*txState)
+	return state
+}
+
+// executor returns the transaction of the context, the db if the context has no transaction.
+// The repositories are shared by all requests, so they never keep the transaction themselves
+func executor(ctx context.Context, db dbContext) dbContext {
+	if state := txFromContext(ctx); state != nil {
+		return state.tx
+	}
+	return db
+}
+
+// TxManager runs the units of work in the transactions. The transaction is carried by the context of the unit
+// of work, so the repositories called with this context execute their queries in it
+type TxManager struct {
+	db        *sqlx.DB
+	isolation sql.IsolationLevel
+	retries   int
+}
+
+// NewTxManager creates the manager which retries the transaction failed on the serialization failure
+// or the deadlock up to the retries times
+func NewTxManager(db *sqlx.DB, retries int) *TxManager {
+	return &TxManager{db: db, retries: max(retries, 0)}
+}
+
+// WithIsolation returns the copy of the manager which starts the transactions with the isolation level
+func (m *TxManager) WithIsolation(level sql.IsolationLevel) *TxManager {
+	copied := *m
+	copied.isolation = level
+	return &copied
+}
+
+// Do executes the fn in the transaction, the fn must use the passed context. The nested call executes the fn
+// in the savepoint of the outer transaction, so its error rolls back only the changes of the nested fn.
+// The fn of the top-level transaction can be executed several times, if the transaction is retried
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if state := txFromContext(ctx); state != nil {
+		return state.savepoint(ctx, "tx.Do", fn)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := beginTx(ctx, m.db, &sql.TxOptions{Isolation: m.isolation}, "tx.Do", fn)
+		if err == nil || attempt > m.retries || !isRetryableTxError(err) {
+			return err
+		}
+
+		slog.Warn("retry the transaction", "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(time.Duration(attempt) * txRetryDelay):
+		}
+	}
+}
+
+// beginTx executes the fn in the new transaction on the db connection.
+// The transaction is rolled back if the fn returns an error or panics
+func beginTx(ctx context.Context, db *sqlx.DB, opts *sql.TxOptions, source string, fn func(ctx context.Context) error) (err error) {
+	tx, err := db.BeginTxx(ctx, opts)
+	if err != nil {
+		return wrapQueryExecError(source, fmt.Errorf("failed to begin the transaction: %w", err))
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, &txState{tx: tx})); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return wrapQueryExecError(source, fmt.Errorf("failed to commit the transaction: %w", err))
+	}
+
+	return nil
+}
+
+// savepoint executes the fn in the savepoint of the transaction, the savepoint is rolled back if the fn returns an error
+func (s *txState) savepoint(ctx context.Context, source string, fn func(ctx context.Context) error) error {
+	s.savepoints++
+	name := fmt.Sprintf("sp_%d", s.savepoints)
+
+	if _, err := s.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return wrapQueryExecError(source, fmt.Errorf("failed to create the savepoint: %w", err))
+	}
+
+	if err := fn(ctx); err != nil {
+		if _, rollbackErr := s.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			slog.Error("failed to roll back to the savepoint", "savepoint", name, "err", rollbackErr)
+		}
+		return err
+	}
+
+	if _, err := s.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return wrapQueryExecError(source, fmt.Errorf("failed to release the savepoint: %w", err))
+	}
+	return nil
+}
+
+// execTx executes the txActions in the transaction on the db connection.
+// The transaction is rolled back if txActions returns an error or panics.
+// If the context already has a transaction, the txActions are executed in it
+func execTx(ctx context.Context, db dbContext, source string, txActions func(tx dbContext) error) error {
+	if state := txFromContext(ctx); state != nil {
+		return txActions(state.tx)
+	}
+
+	conn, ok := db.(*sqlx.DB)
+	if !ok {
+		debugMessage := fmt.Sprintf("execute the tx, unsupport database type: %v", reflect.TypeOf(db))
+		return dto.NewError(500, "internal server error", source, nil, debugMessage)
+	}
+
+	return beginTx(ctx, conn, &sql.TxOptions{}, source, func(ctx context.Context) error {
+		return txActions(txFromContext(ctx).tx)
+	})
+}
@@ -8,19 +8,97 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-func configureRouter(router *mux.Router, songRepo *repository.Song) {
+func configureRouter(router *mux.Router, txManager *repository.TxManager, songRepo *repository.Song, enrichmentRepo *repository.Enrichment, artistRepo *repository.Artist, albumRepo *repository.Album, tagRepo *repository.Tag, linkRepo *repository.Link, searchRepo *repository.Search, suggestCache *handler.SuggestCache) {
+
+	router.Use(middleware.RequestID)
 
 	router.PathPrefix("/swagger/").Handler(httpSwagger.WrapHandler)
 
 	router.Handle("/api/v1/songs", middleware.Log(handler.GetSongs(songRepo))).Methods("GET")
 
+	router.Handle("/api/v1/songs/search", middleware.Log(handler.SearchSongs(songRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/import", middleware.Log(handler.ImportSongs(txManager, songRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/export", middleware.Log(handler.ExportSongs(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/duplicates", middleware.Log(handler.GetSongDuplicates(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}/merge", middleware.Log(handler.MergeSongs(txManager, songRepo))).Methods("POST")
+
 	router.Handle("/api/v1/songs/{id}/lyrics", middleware.Log(handler.GetSongText(songRepo))).Methods("GET")
 
-	router.Handle("/api/v1/songs/{id}", middleware.Log(handler.DeleteSong(songRepo))).Methods("DELETE")
+	router.Handle("/api/v1/songs/{id}/lyrics", middleware.Log(handler.UploadSyncedLyrics(txManager, songRepo))).Methods("PUT")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/couplets", middleware.Log(handler.InsertCouplet(txManager, songRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/couplets/{n}", middleware.Log(handler.UpdateCouplet(txManager, songRepo))).Methods("PUT")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/couplets/{n}", middleware.Log(handler.DeleteCouplet(txManager, songRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/line", middleware.Log(handler.GetSyncedLyricsLine(songRepo))).Methods("GET")
 
-	router.Handle("/api/v1/songs/{id}", middleware.Log(handler.UpdateSong(songRepo))).Methods("PATCH")
+	router.Handle("/api/v1/songs/{id}/translations", middleware.Log(handler.GetLyricsTranslations(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}/translations/{lang}", middleware.Log(handler.SaveLyricsTranslation(txManager, songRepo))).Methods("PUT")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/revisions", middleware.Log(handler.GetLyricsRevisions(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/revisions/{rev}/restore", middleware.Log(handler.RestoreLyricsRevision(txManager, songRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/{id}/lyrics/diff", middleware.Log(handler.GetLyricsDiff(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}", middleware.Log(handler.DeleteSong(txManager, songRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/songs/{id}", middleware.Log(handler.UpdateSong(txManager, songRepo))).Methods("PATCH")
 
 	router.Handle("/api/v1/songs", middleware.Log(handler.AddSong(songRepo))).Methods("POST")
 
+	router.Handle("/api/v1/songs/{id}/tags", middleware.Log(handler.AttachSongTags(txManager, tagRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/{id}/tags", middleware.Log(handler.DetachSongTags(txManager, tagRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/songs/{id}/links", middleware.Log(handler.GetSongLinks(linkRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}/links", middleware.Log(handler.AddSongLink(txManager, linkRepo))).Methods("POST")
+
+	router.Handle("/api/v1/songs/{id}/links/{link_id}", middleware.Log(handler.UpdateSongLink(txManager, linkRepo))).Methods("PATCH")
+
+	router.Handle("/api/v1/songs/{id}/links/{link_id}", middleware.Log(handler.DeleteSongLink(txManager, linkRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/songs/{id}/enrichment", middleware.Log(handler.GetEnrichmentJob(enrichmentRepo))).Methods("GET")
+
+	router.Handle("/api/v1/songs/{id}/enrichment/retry", middleware.Log(handler.RetryEnrichmentJob(enrichmentRepo))).Methods("POST")
+
+	router.Handle("/api/v1/search", middleware.Log(handler.SearchLyrics(searchRepo))).Methods("GET")
+
+	router.Handle("/api/v1/suggest", middleware.Log(handler.Suggest(searchRepo, suggestCache))).Methods("GET")
+
 	router.Handle("/api/v1/info", middleware.Log(handler.GetSongDetails(songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/artists", middleware.Log(handler.GetArtists(artistRepo))).Methods("GET")
+
+	router.Handle("/api/v1/artists", middleware.Log(handler.AddArtist(artistRepo))).Methods("POST")
+
+	router.Handle("/api/v1/artists/{id}", middleware.Log(handler.GetArtist(artistRepo))).Methods("GET")
+
+	router.Handle("/api/v1/artists/{id}", middleware.Log(handler.UpdateArtist(txManager, artistRepo))).Methods("PATCH")
+
+	router.Handle("/api/v1/artists/{id}", middleware.Log(handler.DeleteArtist(artistRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/artists/{id}/songs", middleware.Log(handler.GetArtistSongs(artistRepo, songRepo))).Methods("GET")
+
+	router.Handle("/api/v1/albums", middleware.Log(handler.GetAlbums(albumRepo))).Methods("GET")
+
+	router.Handle("/api/v1/albums", middleware.Log(handler.AddAlbum(albumRepo))).Methods("POST")
+
+	router.Handle("/api/v1/albums/{id}", middleware.Log(handler.GetAlbum(albumRepo))).Methods("GET")
+
+	router.Handle("/api/v1/albums/{id}", middleware.Log(handler.UpdateAlbum(albumRepo))).Methods("PATCH")
+
+	router.Handle("/api/v1/albums/{id}", middleware.Log(handler.DeleteAlbum(albumRepo))).Methods("DELETE")
+
+	router.Handle("/api/v1/albums/{id}/tracks", middleware.Log(handler.SetAlbumTracks(txManager, albumRepo))).Methods("PUT")
+
+	router.Handle("/api/v1/tags", middleware.Log(handler.GetTags(tagRepo))).Methods("GET")
 }
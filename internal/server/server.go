@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"log"
 	"net"
 	"net/http"
 	"time"
 
 	"github.com/amicie-monami/music-library/config"
+	"github.com/amicie-monami/music-library/internal/domain/dto"
 	"github.com/amicie-monami/music-library/internal/repository"
+	"github.com/amicie-monami/music-library/pkg/cache"
 	"github.com/gorilla/mux"
 	"github.com/jmoiron/sqlx"
 )
@@ -19,9 +22,23 @@ type server struct {
 
 func New(ctx context.Context, config *config.Config, db *sqlx.DB) *server {
 	router := mux.NewRouter()
-	songRepo := repository.NewSong(db)
 
-	configureRouter(router, songRepo)
+	isolation, err := repository.ParseIsolationLevel(config.Database.TxIsolation)
+	if err != nil {
+		log.Fatal(err)
+	}
+	txManager := repository.NewTxManager(db, config.Database.TxRetries).WithIsolation(isolation)
+
+	songRepo := repository.NewSong(db)
+	enrichmentRepo := repository.NewEnrichment(db)
+	artistRepo := repository.NewArtist(db)
+	albumRepo := repository.NewAlbum(db)
+	tagRepo := repository.NewTag(db)
+	linkRepo := repository.NewLink(db)
+	searchRepo := repository.NewSearch(db)
+	suggestCache := cache.New[string, []*dto.Suggestion](time.Duration(config.Suggest.CacheTTL)*time.Second, config.Suggest.CacheSize)
+
+	configureRouter(router, txManager, songRepo, enrichmentRepo, artistRepo, albumRepo, tagRepo, linkRepo, searchRepo, suggestCache)
 	srv := &http.Server{
 		Addr:           config.Server.Addr,
 		ReadTimeout:    time.Duration(config.Server.ReadTimeout) * time.Second,
@@ -0,0 +1,13 @@
+-- enrichment_jobs stores the state of the background enrichment of the song details
+-- by the external song info provider. status takes one value from [pending, running, done, failed]
+CREATE TABLE enrichment_jobs (
+    song_id BIGINT PRIMARY KEY REFERENCES songs(id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX enrichment_jobs_due_idx ON enrichment_jobs (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+ALTER TABLE songs ADD COLUMN group_name VARCHAR(128);
+
+UPDATE songs SET group_name = artists.name FROM artists WHERE artists.id = songs.artist_id;
+
+ALTER TABLE songs ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE songs DROP COLUMN artist_id;
+
+DROP TABLE IF EXISTS artists;
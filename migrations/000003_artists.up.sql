@@ -0,0 +1,17 @@
+CREATE TABLE artists (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(128) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- move the group names of the existing songs to the artists table
+INSERT INTO artists (name) SELECT DISTINCT group_name FROM songs;
+
+ALTER TABLE songs ADD COLUMN artist_id BIGINT REFERENCES artists(id);
+
+UPDATE songs SET artist_id = artists.id FROM artists WHERE artists.name = songs.group_name;
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+ALTER TABLE songs DROP COLUMN group_name;
+
+CREATE INDEX songs_artist_id_idx ON songs (artist_id);
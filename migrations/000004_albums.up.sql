@@ -0,0 +1,18 @@
+CREATE TABLE albums (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(128) NOT NULL,
+    release_date DATE,
+    cover_link VARCHAR(256),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- album_tracks stores the ordered track list of the album, positions start from 1
+CREATE TABLE album_tracks (
+    album_id BIGINT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    song_id BIGINT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    position INT NOT NULL CHECK (position > 0),
+    PRIMARY KEY (album_id, song_id),
+    UNIQUE (album_id, position)
+);
+
+CREATE INDEX album_tracks_song_id_idx ON album_tracks (song_id);
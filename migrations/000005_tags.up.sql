@@ -0,0 +1,15 @@
+-- tags classify songs, kind takes one value from [genre, tag]
+CREATE TABLE tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    kind VARCHAR(16) NOT NULL DEFAULT 'tag' CHECK (kind IN ('genre', 'tag')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE song_tags (
+    song_id BIGINT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    tag_id BIGINT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (song_id, tag_id)
+);
+
+CREATE INDEX song_tags_tag_id_idx ON song_tags (tag_id);
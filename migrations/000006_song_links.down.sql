@@ -0,0 +1,7 @@
+ALTER TABLE song_details ADD COLUMN link VARCHAR(512);
+
+UPDATE song_details SET link = song_links.url
+FROM song_links
+WHERE song_links.song_id = song_details.song_id AND song_links.is_primary;
+
+DROP TABLE IF EXISTS song_links;
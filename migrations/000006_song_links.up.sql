@@ -0,0 +1,36 @@
+-- song_links keeps the links of a song on the streaming platforms,
+-- the primary link is returned as the link of the song details
+CREATE TABLE song_links (
+    id BIGSERIAL PRIMARY KEY,
+    song_id BIGINT NOT NULL REFERENCES songs(id) ON DELETE CASCADE,
+    platform VARCHAR(16) NOT NULL DEFAULT 'other'
+        CHECK (platform IN ('spotify', 'apple', 'soundcloud', 'yandex', 'youtube', 'other')),
+    url VARCHAR(512) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (song_id, url)
+);
+
+-- a song has at most one primary link
+CREATE UNIQUE INDEX song_links_primary_idx ON song_links (song_id) WHERE is_primary;
+
+-- move the links of the existing songs, the platform is detected by the host of the link
+INSERT INTO song_links (song_id, platform, url, is_primary)
+SELECT song_id,
+    CASE
+        WHEN host ~ '(^|\.)(spotify\.com|spoti\.fi)$' THEN 'spotify'
+        WHEN host ~ '(^|\.)(music|itunes)\.apple\.com$' THEN 'apple'
+        WHEN host ~ '(^|\.)(soundcloud\.com|snd\.sc)$' THEN 'soundcloud'
+        WHEN host ~ '(^|\.)music\.yandex\.(ru|com|by|kz)$' THEN 'yandex'
+        WHEN host ~ '(^|\.)(youtube\.com|youtu\.be)$' THEN 'youtube'
+        ELSE 'other'
+    END,
+    link,
+    true
+FROM (
+    SELECT song_id, link, lower(substring(link FROM '^(?:[A-Za-z][A-Za-z0-9+.-]*://)?([^/:?#]+)')) AS host
+    FROM song_details
+    WHERE link IS NOT NULL AND link <> ''
+) AS links;
+
+ALTER TABLE song_details DROP COLUMN link;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS before_write_song_details_tsv ON song_details;
+DROP FUNCTION IF EXISTS update_song_details_tsv();
+
+ALTER TABLE song_details
+    DROP COLUMN IF EXISTS text_tsv_russian,
+    DROP COLUMN IF EXISTS text_tsv_english,
+    DROP COLUMN IF EXISTS text_tsv_simple;
+
+DROP FUNCTION IF EXISTS lyrics_normalize(TEXT);
@@ -0,0 +1,34 @@
+-- lyrics_normalize replaces the escaped line breaks, which the lyrics can be stored with, by the real ones
+CREATE OR REPLACE FUNCTION lyrics_normalize(lyrics TEXT)
+RETURNS TEXT AS $$
+    SELECT replace(lyrics, '\n', E'\n');
+$$ LANGUAGE sql IMMUTABLE;
+
+-- the lyrics are indexed with every supported search configuration
+ALTER TABLE song_details
+    ADD COLUMN text_tsv_russian TSVECTOR,
+    ADD COLUMN text_tsv_english TSVECTOR,
+    ADD COLUMN text_tsv_simple TSVECTOR;
+
+-- update_song_details_tsv keeps the search vectors in sync with the lyrics
+CREATE OR REPLACE FUNCTION update_song_details_tsv()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.text_tsv_russian := to_tsvector('russian', lyrics_normalize(COALESCE(NEW.text, '')));
+    NEW.text_tsv_english := to_tsvector('english', lyrics_normalize(COALESCE(NEW.text, '')));
+    NEW.text_tsv_simple := to_tsvector('simple', lyrics_normalize(COALESCE(NEW.text, '')));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER before_write_song_details_tsv
+BEFORE INSERT OR UPDATE OF text ON song_details
+FOR EACH ROW
+EXECUTE FUNCTION update_song_details_tsv();
+
+-- fill the vectors of the existing lyrics
+UPDATE song_details SET text = text;
+
+CREATE INDEX song_details_text_tsv_russian_idx ON song_details USING GIN (text_tsv_russian);
+CREATE INDEX song_details_text_tsv_english_idx ON song_details USING GIN (text_tsv_english);
+CREATE INDEX song_details_text_tsv_simple_idx ON song_details USING GIN (text_tsv_simple);
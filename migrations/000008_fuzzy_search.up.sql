@@ -0,0 +1,5 @@
+-- pg_trgm provides the similarity of strings, which makes the search tolerant to the typos
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX artists_name_trgm_idx ON artists USING GIN (name gin_trgm_ops);
+CREATE INDEX songs_song_name_trgm_idx ON songs USING GIN (song_name gin_trgm_ops);
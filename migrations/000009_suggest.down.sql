@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS songs_song_name_prefix_idx;
+DROP INDEX IF EXISTS artists_name_prefix_idx;
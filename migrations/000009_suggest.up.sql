@@ -0,0 +1,3 @@
+-- prefix indexes of the autocomplete, the names are matched case-insensitively
+CREATE INDEX artists_name_prefix_idx ON artists (lower(name) text_pattern_ops);
+CREATE INDEX songs_song_name_prefix_idx ON songs (lower(song_name) text_pattern_ops);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS songs_normalized_name_idx;
+
+ALTER TABLE songs DROP COLUMN IF EXISTS legacy_duplicate;
+
+DROP FUNCTION IF EXISTS song_name_normalize(TEXT);
@@ -0,0 +1,20 @@
+-- song_name_normalize folds the case and the spaces of the name,
+-- the songs which names differ only by them are duplicates
+CREATE OR REPLACE FUNCTION song_name_normalize(name TEXT)
+RETURNS TEXT AS $$
+    SELECT lower(regexp_replace(btrim(name), '\s+', ' ', 'g'));
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+-- legacy_duplicate marks the duplicates which were added before the uniqueness constraint,
+-- they are excluded from it until they are merged into the other songs
+ALTER TABLE songs ADD COLUMN legacy_duplicate BOOLEAN NOT NULL DEFAULT false;
+
+-- the oldest song of the duplicates keeps the place in the constraint
+UPDATE songs SET legacy_duplicate = true
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY artist_id, song_name_normalize(song_name) ORDER BY id) AS number
+    FROM songs
+) AS ranked
+WHERE ranked.id = songs.id AND ranked.number > 1;
+
+CREATE UNIQUE INDEX songs_normalized_name_idx ON songs (artist_id, song_name_normalize(song_name)) WHERE NOT legacy_duplicate;
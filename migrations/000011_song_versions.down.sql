@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS after_write_song_tags_touch ON song_tags;
+DROP TRIGGER IF EXISTS after_write_song_links_touch ON song_links;
+DROP TRIGGER IF EXISTS after_update_song_details_touch ON song_details;
+DROP FUNCTION IF EXISTS touch_song();
+
+DROP TRIGGER IF EXISTS before_update_song_details_version ON song_details;
+DROP TRIGGER IF EXISTS before_update_songs_version ON songs;
+DROP FUNCTION IF EXISTS bump_row_version();
+
+ALTER TABLE song_details DROP COLUMN IF EXISTS version, DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE songs DROP COLUMN IF EXISTS version, DROP COLUMN IF EXISTS updated_at;
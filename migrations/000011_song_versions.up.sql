@@ -0,0 +1,58 @@
+-- the version of the row is incremented on each update of the row
+ALTER TABLE songs
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+ALTER TABLE song_details
+    ADD COLUMN version BIGINT NOT NULL DEFAULT 1,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE OR REPLACE FUNCTION bump_row_version()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.version = OLD.version THEN
+        NEW.version := OLD.version + 1;
+    END IF;
+    NEW.updated_at := now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER before_update_songs_version
+BEFORE UPDATE ON songs
+FOR EACH ROW
+EXECUTE FUNCTION bump_row_version();
+
+CREATE TRIGGER before_update_song_details_version
+BEFORE UPDATE ON song_details
+FOR EACH ROW
+EXECUTE FUNCTION bump_row_version();
+
+-- the version of the song is the version of the whole song: it is also incremented
+-- when the details, the links or the tags of the song change
+CREATE OR REPLACE FUNCTION touch_song()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE songs SET version = version + 1 WHERE id = OLD.song_id;
+    ELSE
+        UPDATE songs SET version = version + 1 WHERE id = NEW.song_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER after_update_song_details_touch
+AFTER UPDATE ON song_details
+FOR EACH ROW
+EXECUTE FUNCTION touch_song();
+
+CREATE TRIGGER after_write_song_links_touch
+AFTER INSERT OR UPDATE OR DELETE ON song_links
+FOR EACH ROW
+EXECUTE FUNCTION touch_song();
+
+CREATE TRIGGER after_write_song_tags_touch
+AFTER INSERT OR DELETE ON song_tags
+FOR EACH ROW
+EXECUTE FUNCTION touch_song();
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS after_write_song_details_lyrics_revision ON song_details;
+DROP FUNCTION IF EXISTS record_lyrics_revision();
+DROP TABLE IF EXISTS lyrics_revisions;
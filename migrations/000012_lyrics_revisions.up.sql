@@ -0,0 +1,48 @@
+-- every change of the lyrics of the song is stored as the revision, the revisions are numbered from 1 for each song
+CREATE TABLE lyrics_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL,
+    text TEXT,
+    author TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (song_id, revision)
+);
+
+-- the current lyrics are the first revisions
+INSERT INTO lyrics_revisions (song_id, revision, text, author, reason, created_at)
+SELECT song_id, 1, text, 'system', 'initial revision', updated_at
+FROM song_details
+WHERE text IS NOT NULL;
+
+-- the author and the reason of the change are set by the transaction of the change,
+-- the changes made without them are made by the system
+CREATE OR REPLACE FUNCTION record_lyrics_revision()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.text IS NOT DISTINCT FROM OLD.text THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'INSERT' AND NEW.text IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    -- the row of the details is locked by the change, so the revisions of the song are numbered one by one
+    INSERT INTO lyrics_revisions (song_id, revision, text, author, reason)
+    VALUES (
+        NEW.song_id,
+        COALESCE((SELECT max(revision) FROM lyrics_revisions WHERE song_id = NEW.song_id), 0) + 1,
+        NEW.text,
+        COALESCE(NULLIF(current_setting('music_library.revision_author', true), ''), 'system'),
+        COALESCE(current_setting('music_library.revision_reason', true), '')
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER after_write_song_details_lyrics_revision
+AFTER INSERT OR UPDATE OF text ON song_details
+FOR EACH ROW
+EXECUTE FUNCTION record_lyrics_revision();
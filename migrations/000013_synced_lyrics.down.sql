@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS after_update_song_details_synced_lyrics ON song_details;
+DROP FUNCTION IF EXISTS drop_stale_synced_lyrics();
+DROP TABLE IF EXISTS synced_lyrics_lines;
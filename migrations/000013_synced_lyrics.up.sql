@@ -0,0 +1,32 @@
+-- synced_lyrics_lines keeps the timing of the lyrics: each line starts at start_ms from the beginning of the song
+-- and lasts until the next line, words are the timed words of the enhanced LRC
+CREATE TABLE synced_lyrics_lines (
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    line_no INT NOT NULL,
+    start_ms BIGINT NOT NULL CHECK (start_ms >= 0),
+    text TEXT NOT NULL,
+    words JSONB,
+    PRIMARY KEY (song_id, line_no)
+);
+
+CREATE INDEX synced_lyrics_lines_start_idx ON synced_lyrics_lines (song_id, start_ms);
+
+-- the text of the synced lyrics is the lines joined in the order of their time,
+-- the timing of the other text is dropped when the text changes
+CREATE OR REPLACE FUNCTION drop_stale_synced_lyrics()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.text IS DISTINCT FROM (
+        SELECT string_agg(text, E'\n' ORDER BY line_no) FROM synced_lyrics_lines WHERE song_id = NEW.song_id
+    ) THEN
+        DELETE FROM synced_lyrics_lines WHERE song_id = NEW.song_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER after_update_song_details_synced_lyrics
+AFTER UPDATE OF text ON song_details
+FOR EACH ROW
+WHEN (NEW.text IS DISTINCT FROM OLD.text)
+EXECUTE FUNCTION drop_stale_synced_lyrics();
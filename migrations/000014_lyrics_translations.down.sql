@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS after_write_lyrics_translations_touch ON lyrics_translations;
+DROP TABLE IF EXISTS lyrics_translations;
@@ -0,0 +1,16 @@
+-- lyrics_translations keeps the translations of the lyrics, lang is the canonical BCP 47 tag of the translation
+CREATE TABLE lyrics_translations (
+    song_id BIGINT NOT NULL REFERENCES songs (id) ON DELETE CASCADE,
+    lang VARCHAR(35) NOT NULL,
+    text TEXT NOT NULL,
+    author TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (song_id, lang)
+);
+
+-- the translations are the part of the song, so they change its version
+CREATE TRIGGER after_write_lyrics_translations_touch
+AFTER INSERT OR UPDATE OR DELETE ON lyrics_translations
+FOR EACH ROW
+EXECUTE FUNCTION touch_song();
@@ -1,21 +1,34 @@
 DELETE FROM songs;
 DELETE FROM song_details;
+DELETE FROM artists;
 
-INSERT INTO songs (group_name, song_name) VALUES 
-('The Beatles', 'Hey Jude'),
-('Queen', 'Bohemian Rhapsody'),
-('Pink Floyd', 'Comfortably Numb'),
-('Led Zeppelin', 'Stairway to Heaven'),
-('The Rolling Stones', 'Paint It Black'),
-('The Who', 'Baba O''Riley'),
-('Nirvana', 'Smells Like Teen Spirit'),
-('Metallica', 'Enter Sandman'),
-('AC/DC', 'Thunderstruck'),
-('Guns N'' Roses', 'Sweet Child O'' Mine');
+INSERT INTO artists (name) VALUES
+('The Beatles'),
+('Queen'),
+('Pink Floyd'),
+('Led Zeppelin'),
+('The Rolling Stones'),
+('The Who'),
+('Nirvana'),
+('Metallica'),
+('AC/DC'),
+('Guns N'' Roses');
 
-WITH random_services AS (
-    SELECT unnest(array['spotify.com', 'apple.com', 'soundcloud.com']) as service
-)
+INSERT INTO songs (artist_id, song_name)
+SELECT artists.id, data.song_name FROM (VALUES
+    ('The Beatles', 'Hey Jude', 1),
+    ('Queen', 'Bohemian Rhapsody', 2),
+    ('Pink Floyd', 'Comfortably Numb', 3),
+    ('Led Zeppelin', 'Stairway to Heaven', 4),
+    ('The Rolling Stones', 'Paint It Black', 5),
+    ('The Who', 'Baba O''Riley', 6),
+    ('Nirvana', 'Smells Like Teen Spirit', 7),
+    ('Metallica', 'Enter Sandman', 8),
+    ('AC/DC', 'Thunderstruck', 9),
+    ('Guns N'' Roses', 'Sweet Child O'' Mine', 10)
+) AS data (group_name, song_name, position)
+JOIN artists ON artists.name = data.group_name
+ORDER BY data.position;
 
 UPDATE song_details SET
     release_date = (
@@ -33,13 +46,6 @@ UPDATE song_details SET
         ELSE null END
     ),
     
-    link = (
-        SELECT service || '/track/' || song_id 
-        FROM random_services 
-        ORDER BY random() 
-        LIMIT 1
-    ),
-
     text = (
         CASE song_id
         WHEN 1 THEN 'Hey Jude, don''t make it bad\n\nTake a sad song and make it better\n\nRemember to let her into your heart\n\nThen you can start to make it better'
@@ -54,3 +60,12 @@ UPDATE song_details SET
         WHEN 10 THEN 'She''s got a smile that it seems to me\n\nReminds me of childhood memories\n\nWhere everything was as fresh as the bright blue sky'
         ELSE 'No lyrics available' END
     );
+
+INSERT INTO song_links (song_id, platform, url, is_primary)
+SELECT songs.id, links.platform, links.host || '/track/' || songs.id, links.platform = 'spotify'
+FROM songs
+CROSS JOIN (VALUES
+    ('spotify', 'open.spotify.com'),
+    ('apple', 'music.apple.com'),
+    ('soundcloud', 'soundcloud.com')
+) AS links (platform, host);
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is an in-process key-value cache with the expiration of entries.
+// When the cache is full, the expired entries are removed first,
+// then the entry which expires the earliest
+type Cache[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	// expirations keeps the entries ordered by their expiration time, the earliest first.
+	// All entries live for the same ttl, so the entry set last goes to the back
+	expirations *list.List
+	ttl         time.Duration
+	size        int
+	now         func() time.Time
+}
+
+// New creates the cache which keeps up to size entries for the ttl
+func New[K comparable, V any](ttl time.Duration, size int) *Cache[K, V] {
+	return &Cache[K, V]{
+		entries:     make(map[K]*list.Element),
+		expirations: list.New(),
+		ttl:         ttl,
+		size:        max(size, 1),
+		now:         time.Now,
+	}
+}
+
+// WithClock replaces the clock of the cache, it's used by the tests
+func (c *Cache[K, V]) WithClock(now func() time.Time) *Cache[K, V] {
+	c.now = now
+	return c
+}
+
+// Get returns the value of the key if it's cached and hasn't expired
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok || !c.now().Before(element.Value.(*entry[K, V]).expiresAt) {
+		var zero V
+		return zero, false
+	}
+	return element.Value.(*entry[K, V]).value, true
+}
+
+// Set caches the value of the key for the ttl
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.expirations.MoveToBack(element)
+		return
+	}
+
+	if len(c.entries) >= c.size {
+		c.evict()
+	}
+	c.entries[key] = c.expirations.PushBack(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+}
+
+// Len returns the number of the cached entries including the expired ones
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evict frees the place for a new entry, must be called under the lock.
+// The expired entries are at the front of the expirations, so each entry is visited once
+func (c *Cache[K, V]) evict() {
+	now := c.now()
+
+	for front := c.expirations.Front(); front != nil && !now.Before(front.Value.(*entry[K, V]).expiresAt); front = c.expirations.Front() {
+		c.remove(front)
+	}
+
+	if len(c.entries) >= c.size {
+		c.remove(c.expirations.Front())
+	}
+}
+
+func (c *Cache[K, V]) remove(element *list.Element) {
+	c.expirations.Remove(element)
+	delete(c.entries, element.Value.(*entry[K, V]).key)
+}
@@ -0,0 +1,72 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amicie-monami/music-library/pkg/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheExpiration(t *testing.T) {
+	now := time.Now()
+	c := cache.New[string, int](time.Minute, 10).WithClock(func() time.Time { return now })
+
+	c.Set("met", 1)
+
+	value, ok := c.Get("met")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	now = now.Add(time.Minute)
+	_, ok = c.Get("met")
+	assert.False(t, ok)
+}
+
+func TestCacheEviction(t *testing.T) {
+	now := time.Now()
+	c := cache.New[string, int](time.Minute, 2).WithClock(func() time.Time { return now })
+
+	c.Set("a", 1)
+	now = now.Add(time.Second)
+	c.Set("b", 2)
+	now = now.Add(time.Second)
+
+	//the earliest expiring entry is evicted
+	c.Set("c", 3)
+	assert.Equal(t, 2, c.Len())
+
+	_, ok := c.Get("a")
+	assert.False(t, ok)
+
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+
+	//the expired entries are evicted first
+	now = now.Add(time.Minute)
+	c.Set("d", 4)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCacheEvictionAfterUpdate(t *testing.T) {
+	now := time.Now()
+	c := cache.New[string, int](time.Minute, 2).WithClock(func() time.Time { return now })
+
+	c.Set("a", 1)
+	now = now.Add(time.Second)
+	c.Set("b", 2)
+	now = now.Add(time.Second)
+
+	//the updated entry expires later than the entry set after it
+	c.Set("a", 3)
+	now = now.Add(time.Second)
+	c.Set("c", 4)
+	assert.Equal(t, 2, c.Len())
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+
+	value, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+}
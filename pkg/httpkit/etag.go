@@ -0,0 +1,34 @@
+package httpkit
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag returns the strong entity tag of the version of the entity
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// MatchETag reports whether the list of the entity tags of the If-Match or If-None-Match header matches the etag,
+// "*" matches any etag. The weak comparison ignores the W/ prefix, the strong one never matches the weak tags
+func MatchETag(header string, etag string, weak bool) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+
+		if tag == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
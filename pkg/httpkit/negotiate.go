@@ -0,0 +1,89 @@
+package httpkit
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NegotiateContentType returns the offered media type which the client prefers by the Accept header of the request.
+// Each offer gets the quality of the most specific media range matching it, the offers with the same quality
+// are ranked by their order. The first offer is returned if the header is missing or accepts none of the offers
+func NegotiateContentType(r *http.Request, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	ranges := parseAccept(r.Header.Values("Accept"))
+	if len(ranges) == 0 {
+		return offers[0]
+	}
+
+	best, bestQuality := offers[0], 0.0
+	for _, offer := range offers {
+		if quality := offerQuality(offer, ranges); quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+	return best
+}
+
+// acceptRange is the media range of the Accept header with its quality
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+func parseAccept(headers []string) []acceptRange {
+	ranges := make([]acceptRange, 0)
+	for _, header := range headers {
+		for _, part := range strings.Split(header, ",") {
+			mediaType, params, _ := strings.Cut(part, ";")
+			mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+			if mediaType == "" {
+				continue
+			}
+
+			quality := 1.0
+			for _, param := range strings.Split(params, ";") {
+				name, value, _ := strings.Cut(param, "=")
+				if strings.TrimSpace(name) != "q" {
+					continue
+				}
+
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && q >= 0 && q <= 1 {
+					quality = q
+				}
+			}
+
+			ranges = append(ranges, acceptRange{mediaType: mediaType, quality: quality})
+		}
+	}
+	return ranges
+}
+
+// offerQuality returns the quality of the most specific range matching the offer: the exact type,
+// then type/*, then */*. The offer which no range matches isn't acceptable
+func offerQuality(offer string, ranges []acceptRange) float64 {
+	mainType, _, _ := strings.Cut(offer, "/")
+
+	quality, specificity := 0.0, 0
+	for _, accept := range ranges {
+		var rangeSpecificity int
+		switch accept.mediaType {
+		case offer:
+			rangeSpecificity = 3
+		case mainType + "/*":
+			rangeSpecificity = 2
+		case "*/*":
+			rangeSpecificity = 1
+		default:
+			continue
+		}
+
+		if rangeSpecificity > specificity {
+			quality, specificity = accept.quality, rangeSpecificity
+		}
+	}
+	return quality
+}
@@ -3,7 +3,9 @@ package httpkit
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 )
 
 // GetRequiredIntParam ...
@@ -36,6 +38,24 @@ func GetStrParam(key string, r *http.Request) string {
 	return r.URL.Query().Get(key)
 }
 
+// GetRawStrParam returns the query param which can contain the semicolons. The pairs of the query are only
+// separated by "&", unlike r.URL.Query(), which drops the pairs with the unescaped semicolons
+func GetRawStrParam(key string, r *http.Request) (string, error) {
+	for _, pair := range strings.Split(r.URL.RawQuery, "&") {
+		name, value, _ := strings.Cut(pair, "=")
+		if name, err := url.QueryUnescape(name); err != nil || name != key {
+			continue
+		}
+
+		value, err := url.QueryUnescape(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode %s param", key)
+		}
+		return value, nil
+	}
+	return "", nil
+}
+
 func getQueryParam(key string, r *http.Request) (string, error) {
 	param := r.URL.Query().Get(key)
 	if param == "" {
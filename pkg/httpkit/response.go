@@ -1,11 +1,16 @@
 package httpkit
 
 import (
+	"encoding/json"
+	"log/slog"
 	"net/http"
 
 	"github.com/pawpawchat/core/pkg/response"
 )
 
+// ProblemContentType is the content type of the problem details of RFC 7807
+const ProblemContentType = "application/problem+json"
+
 func sendResponse(w http.ResponseWriter, code int, data any) {
 	if data != nil {
 		response.Json().Code(code).Body(data).MustWrite(w)
@@ -22,6 +27,10 @@ func BadRequest(w http.ResponseWriter, data any) {
 	sendResponse(w, http.StatusBadRequest, data)
 }
 
+func Conflict(w http.ResponseWriter, data any) {
+	sendResponse(w, http.StatusConflict, data)
+}
+
 func InternalError(w http.ResponseWriter, data any) {
 	sendResponse(w, http.StatusInternalServerError, data)
 }
@@ -33,3 +42,12 @@ func Created(w http.ResponseWriter, data any) {
 func Ok(w http.ResponseWriter, data any) {
 	sendResponse(w, http.StatusOK, data)
 }
+
+// SendProblem sends the problem details of RFC 7807 with the application/problem+json content type
+func SendProblem(w http.ResponseWriter, code int, problem any) {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.Error("failed to write the problem", "err", err)
+	}
+}
@@ -0,0 +1,31 @@
+package httpkit_test
+
+import (
+	"testing"
+
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchETag(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Header      string
+		Weak        bool
+		Match       bool
+	}{
+		{"Same tag", `"3"`, false, true},
+		{"Other tag", `"2"`, false, false},
+		{"Any tag", "*", false, true},
+		{"List of tags", `"1", "2" ,"3"`, false, true},
+		{"Weak tag in strong comparison", `W/"3"`, false, false},
+		{"Weak tag in weak comparison", `W/"3"`, true, true},
+		{"Unquoted tag", "3", true, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			assert.Equal(t, tc.Match, httpkit.MatchETag(tc.Header, httpkit.ETag(3), tc.Weak))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package httpkit_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/amicie-monami/music-library/pkg/httpkit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	offers := []string{"application/json", "application/problem+json"}
+
+	testCases := []struct {
+		Description string
+		Accept      string
+		Expected    string
+	}{
+		{"Missing header", "", "application/json"},
+		{"Any type", "*/*", "application/json"},
+		{"Problem", "application/problem+json", "application/problem+json"},
+		{"Problem and any type", "application/problem+json, */*;q=0.8", "application/problem+json"},
+		{"Json is preferred", "application/json;q=1, application/problem+json;q=0.5", "application/json"},
+		{"Same quality", "application/problem+json, application/json", "application/json"},
+		{"Json is excluded", "application/*, application/json;q=0", "application/problem+json"},
+		{"Nothing is acceptable", "text/html", "application/json"},
+		{"Case and spaces", " Application/Problem+JSON ; q=0.9 ", "application/problem+json"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/", nil)
+			if tc.Accept != "" {
+				request.Header.Set("Accept", tc.Accept)
+			}
+
+			assert.Equal(t, tc.Expected, httpkit.NegotiateContentType(request, offers...))
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// operations of the JSON Patch document
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// Operation is the operation of the JSON Patch document (RFC 6902). The value keeps the raw json,
+// so the null value differs from the missing one
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Error is the error of the operation of the patch. The field is the member of the operation
+// which caused the error, the failed test operation is reported with TestFailed
+type Error struct {
+	Index      int
+	Field      string
+	Message    string
+	TestFailed bool
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("operation %d: %s: %s", e.Index, e.Field, e.Message)
+}
+
+// Apply applies the operations to the copy of the document decoded by encoding/json and returns the patched copy.
+// The operations are applied in order, the patch stops on the first error and the document stays unchanged
+func Apply(doc any, ops []Operation) (any, error) {
+	doc, err := deepCopy(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, op := range ops {
+		if doc, err = applyOperation(doc, op); err != nil {
+			if patchErr, ok := err.(*Error); ok {
+				patchErr.Index = idx
+			}
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// Merge applies the JSON Merge Patch (RFC 7396) to the copy of the document decoded by encoding/json and returns
+// the patched copy. The null members of the patch remove the members of the document, the objects are merged
+// recursively, any other value replaces the member
+func Merge(doc any, patch any) (any, error) {
+	doc, err := deepCopy(doc)
+	if err != nil {
+		return nil, err
+	}
+	return merge(doc, patch), nil
+}
+
+func merge(doc any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	docObject, ok := doc.(map[string]any)
+	if !ok {
+		docObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(docObject, key)
+			continue
+		}
+		docObject[key] = merge(docObject[key], value)
+	}
+	return docObject
+}
+
+func applyOperation(doc any, op Operation) (any, error) {
+	path, err := parsePointer(op.Path)
+	if err != nil {
+		return nil, &Error{Field: "path", Message: err.Error()}
+	}
+
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if len(op.Value) == 0 {
+			return nil, &Error{Field: "value", Message: fmt.Sprintf("value is required by the %s operation", op.Op)}
+		}
+
+		var value any
+		if err := json.Unmarshal(op.Value, &value); err != nil {
+			return nil, &Error{Field: "value", Message: err.Error()}
+		}
+
+		switch op.Op {
+		case OpAdd:
+			return add(doc, path, value)
+		case OpReplace:
+			return replace(doc, path, value)
+		default:
+			current, err := get(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, &Error{Field: "value", Message: fmt.Sprintf("value of %s isn't equal to %s", op.Path, op.Value), TestFailed: true}
+			}
+			return doc, nil
+		}
+
+	case OpRemove:
+		doc, _, err := remove(doc, path)
+		return doc, err
+
+	case OpMove, OpCopy:
+		from, err := parsePointer(op.From)
+		if err != nil {
+			return nil, &Error{Field: "from", Message: err.Error()}
+		}
+
+		var value any
+		if op.Op == OpMove {
+			if len(from) < len(path) && reflect.DeepEqual(from, path[:len(from)]) {
+				return nil, &Error{Field: "from", Message: fmt.Sprintf("%s can't be moved into its own child %s", op.From, op.Path)}
+			}
+			doc, value, err = remove(doc, from)
+		} else {
+			value, err = get(doc, from)
+			if err == nil {
+				value, err = deepCopy(value)
+			}
+		}
+
+		if err != nil {
+			if patchErr, ok := err.(*Error); ok {
+				patchErr.Field = "from"
+			}
+			return nil, err
+		}
+		return add(doc, path, value)
+
+	default:
+		message := fmt.Sprintf("op=%s, but must be one of [add, remove, replace, move, copy, test]", op.Op)
+		return nil, &Error{Field: "op", Message: message}
+	}
+}
+
+// parsePointer splits the JSON Pointer (RFC 6901) into the unescaped reference tokens, the empty pointer is the whole document
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer=%s, but must start with /", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for idx, token := range tokens {
+		escapes := strings.Count(token, "~")
+		if strings.Count(token, "~0")+strings.Count(token, "~1") != escapes {
+			return nil, fmt.Errorf("pointer=%s has the invalid escape sequence, only ~0 and ~1 are allowed", pointer)
+		}
+		tokens[idx] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	node := doc
+	for idx, token := range path {
+		switch parent := node.(type) {
+		case map[string]any:
+			child, ok := parent[token]
+			if !ok {
+				return nil, pathNotFound(path[:idx+1])
+			}
+			node = child
+		case []any:
+			arrayIdx, err := arrayIndex(token, len(parent)-1)
+			if err != nil {
+				return nil, &Error{Field: "path", Message: err.Error()}
+			}
+			node = parent[arrayIdx]
+		default:
+			return nil, pathNotFound(path[:idx+1])
+		}
+	}
+	return node, nil
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent any, token string) (any, error) {
+		switch parent := parent.(type) {
+		case map[string]any:
+			parent[token] = value
+			return parent, nil
+		case []any:
+			if token == "-" {
+				return append(parent, value), nil
+			}
+
+			idx, err := arrayIndex(token, len(parent))
+			if err != nil {
+				return nil, &Error{Field: "path", Message: err.Error()}
+			}
+
+			parent = append(parent, nil)
+			copy(parent[idx+1:], parent[idx:])
+			parent[idx] = value
+			return parent, nil
+		default:
+			return nil, pathNotFound(path)
+		}
+	})
+}
+
+func remove(doc any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+
+	var removed any
+	doc, err := update(doc, path, func(parent any, token string) (any, error) {
+		switch parent := parent.(type) {
+		case map[string]any:
+			value, ok := parent[token]
+			if !ok {
+				return nil, pathNotFound(path)
+			}
+			removed = value
+			delete(parent, token)
+			return parent, nil
+		case []any:
+			idx, err := arrayIndex(token, len(parent)-1)
+			if err != nil {
+				return nil, &Error{Field: "path", Message: err.Error()}
+			}
+			removed = parent[idx]
+			return append(parent[:idx], parent[idx+1:]...), nil
+		default:
+			return nil, pathNotFound(path)
+		}
+	})
+	return doc, removed, err
+}
+
+func replace(doc any, path []string, value any) (any, error) {
+	if _, err := get(doc, path); err != nil {
+		return nil, err
+	}
+
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return update(doc, path, func(parent any, token string) (any, error) {
+		switch parent := parent.(type) {
+		case map[string]any:
+			parent[token] = value
+		case []any:
+			idx, _ := arrayIndex(token, len(parent)-1)
+			parent[idx] = value
+		}
+		return parent, nil
+	})
+}
+
+// update walks the document to the parent of the target and replaces the parent with the result of the fn,
+// the arrays are replaced because the fn can reallocate them
+func update(node any, path []string, fn func(parent any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	switch parent := node.(type) {
+	case map[string]any:
+		child, ok := parent[path[0]]
+		if !ok {
+			return nil, pathNotFound(path[:1])
+		}
+
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		parent[path[0]] = child
+		return parent, nil
+
+	case []any:
+		idx, err := arrayIndex(path[0], len(parent)-1)
+		if err != nil {
+			return nil, &Error{Field: "path", Message: err.Error()}
+		}
+
+		child, err := update(parent[idx], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		parent[idx] = child
+		return parent, nil
+
+	default:
+		return nil, pathNotFound(path[:1])
+	}
+}
+
+// arrayIndex parses the index of the array element, the index can't have the leading zeros and can't exceed the max
+func arrayIndex(token string, max int) (int, error) {
+	idx, err := strconv.Atoi(token)
+	if err != nil || idx < 0 || strconv.Itoa(idx) != token {
+		return 0, fmt.Errorf("index=%s, but must be a non-negative integer without leading zeros", token)
+	}
+
+	if idx > max {
+		return 0, fmt.Errorf("index=%d is out of the array bounds", idx)
+	}
+	return idx, nil
+}
+
+func pathNotFound(path []string) *Error {
+	tokens := make([]string, 0, len(path))
+	for _, token := range path {
+		tokens = append(tokens, strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return &Error{Field: "path", Message: fmt.Sprintf("/%s doesn't exist", strings.Join(tokens, "/"))}
+}
+
+// deepCopy copies the document decoded by encoding/json, the patches don't change the original document
+func deepCopy(doc any) (any, error) {
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var copied any
+	if err := json.Unmarshal(data, &copied); err != nil {
+		return nil, err
+	}
+	return copied, nil
+}
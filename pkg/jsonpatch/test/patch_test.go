@@ -0,0 +1,179 @@
+package jsonpatch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/amicie-monami/music-library/pkg/jsonpatch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, data string) any {
+	var value any
+	require.NoError(t, json.Unmarshal([]byte(data), &value))
+	return value
+}
+
+func TestApply(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Doc         string
+		Patch       string
+		Result      string
+		ErrField    string
+		TestFailed  bool
+	}{
+		{
+			Description: "Add object member",
+			Doc:         `{"foo": "bar"}`,
+			Patch:       `[{"op": "add", "path": "/baz", "value": "qux"}]`,
+			Result:      `{"foo": "bar", "baz": "qux"}`,
+		},
+		{
+			Description: "Add array element",
+			Doc:         `{"foo": ["bar", "baz"]}`,
+			Patch:       `[{"op": "add", "path": "/foo/1", "value": "qux"}]`,
+			Result:      `{"foo": ["bar", "qux", "baz"]}`,
+		},
+		{
+			Description: "Add to the end of array",
+			Doc:         `{"foo": ["bar"]}`,
+			Patch:       `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`,
+			Result:      `{"foo": ["bar", ["abc", "def"]]}`,
+		},
+		{
+			Description: "Remove array element",
+			Doc:         `{"foo": ["bar", "qux", "baz"]}`,
+			Patch:       `[{"op": "remove", "path": "/foo/1"}]`,
+			Result:      `{"foo": ["bar", "baz"]}`,
+		},
+		{
+			Description: "Replace with null",
+			Doc:         `{"baz": "qux", "foo": "bar"}`,
+			Patch:       `[{"op": "replace", "path": "/baz", "value": null}]`,
+			Result:      `{"baz": null, "foo": "bar"}`,
+		},
+		{
+			Description: "Move array element",
+			Doc:         `{"foo": ["all", "grass", "cows", "eat"]}`,
+			Patch:       `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`,
+			Result:      `{"foo": ["all", "cows", "eat", "grass"]}`,
+		},
+		{
+			Description: "Copy and test escaped member",
+			Doc:         `{"a/b": ["x"], "m~n": 1}`,
+			Patch:       `[{"op": "copy", "from": "/a~1b/0", "path": "/c"}, {"op": "test", "path": "/m~0n", "value": 1}]`,
+			Result:      `{"a/b": ["x"], "m~n": 1, "c": "x"}`,
+		},
+		{
+			Description: "Failed test",
+			Doc:         `{"baz": "qux"}`,
+			Patch:       `[{"op": "test", "path": "/baz", "value": "bar"}]`,
+			ErrField:    "value",
+			TestFailed:  true,
+		},
+		{
+			Description: "Missing target of replace",
+			Doc:         `{"baz": "qux"}`,
+			Patch:       `[{"op": "replace", "path": "/foo", "value": "bar"}]`,
+			ErrField:    "path",
+		},
+		{
+			Description: "Missing parent of add",
+			Doc:         `{"foo": "bar"}`,
+			Patch:       `[{"op": "add", "path": "/baz/bat", "value": "qux"}]`,
+			ErrField:    "path",
+		},
+		{
+			Description: "Array index out of bounds",
+			Doc:         `{"foo": ["bar"]}`,
+			Patch:       `[{"op": "add", "path": "/foo/2", "value": "qux"}]`,
+			ErrField:    "path",
+		},
+		{
+			Description: "Array index with leading zero",
+			Doc:         `{"foo": ["bar", "baz"]}`,
+			Patch:       `[{"op": "remove", "path": "/foo/01"}]`,
+			ErrField:    "path",
+		},
+		{
+			Description: "Move into own child",
+			Doc:         `{"foo": {"bar": 1}}`,
+			Patch:       `[{"op": "move", "from": "/foo", "path": "/foo/bar"}]`,
+			ErrField:    "from",
+		},
+		{
+			Description: "Missing value",
+			Doc:         `{"foo": "bar"}`,
+			Patch:       `[{"op": "add", "path": "/baz"}]`,
+			ErrField:    "value",
+		},
+		{
+			Description: "Unknown operation",
+			Doc:         `{"foo": "bar"}`,
+			Patch:       `[{"op": "append", "path": "/foo", "value": "baz"}]`,
+			ErrField:    "op",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			var ops []jsonpatch.Operation
+			require.NoError(t, json.Unmarshal([]byte(tc.Patch), &ops))
+
+			doc := decode(t, tc.Doc)
+			result, err := jsonpatch.Apply(doc, ops)
+
+			assert.Equal(t, decode(t, tc.Doc), doc, "the original document must stay unchanged")
+
+			if tc.ErrField != "" {
+				var patchErr *jsonpatch.Error
+				require.ErrorAs(t, err, &patchErr)
+				assert.Equal(t, tc.ErrField, patchErr.Field)
+				assert.Equal(t, tc.TestFailed, patchErr.TestFailed)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, decode(t, tc.Result), result)
+		})
+	}
+}
+
+func TestApplyStopsOnError(t *testing.T) {
+	var ops []jsonpatch.Operation
+	patch := `[{"op": "add", "path": "/baz", "value": 1}, {"op": "remove", "path": "/qux"}]`
+	require.NoError(t, json.Unmarshal([]byte(patch), &ops))
+
+	_, err := jsonpatch.Apply(decode(t, `{"foo": "bar"}`), ops)
+
+	var patchErr *jsonpatch.Error
+	require.ErrorAs(t, err, &patchErr)
+	assert.Equal(t, 1, patchErr.Index)
+}
+
+func TestMerge(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Doc         string
+		Patch       string
+		Result      string
+	}{
+		{"Replace member", `{"a": "b"}`, `{"a": "c"}`, `{"a": "c"}`},
+		{"Add member", `{"a": "b"}`, `{"b": "c"}`, `{"a": "b", "b": "c"}`},
+		{"Remove member", `{"a": "b", "b": "c"}`, `{"a": null}`, `{"b": "c"}`},
+		{"Replace array", `{"a": ["b"]}`, `{"a": ["c", "d"]}`, `{"a": ["c", "d"]}`},
+		{"Merge nested object", `{"a": {"b": "c"}}`, `{"a": {"b": "d", "c": null}}`, `{"a": {"b": "d"}}`},
+		{"Replace non-object", `["a", "b"]`, `{"a": "c"}`, `{"a": "c"}`},
+		{"Replace document", `{"a": "b"}`, `["c"]`, `["c"]`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			result, err := jsonpatch.Merge(decode(t, tc.Doc), decode(t, tc.Patch))
+			require.NoError(t, err)
+			assert.Equal(t, decode(t, tc.Result), result)
+		})
+	}
+}
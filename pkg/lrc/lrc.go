@@ -0,0 +1,279 @@
+package lrc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Word is the word of the enhanced LRC line with the time when it starts to be sung
+type Word struct {
+	Start time.Duration
+	Text  string
+}
+
+// Line is the line of the lyrics with the time when it starts to be sung, the line lasts until the next line.
+// Words are set only by the enhanced LRC, the empty line is the instrumental break
+type Line struct {
+	Start time.Duration
+	Text  string
+	Words []Word
+}
+
+// ParseError is the error of the line of the LRC file, Line starts from 1
+type ParseError struct {
+	Line    int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// Parse parses the lyrics in the LRC or the enhanced LRC format and returns the lines ordered by their time.
+// The line with several time tags is repeated at each time, the metadata tags are skipped
+// except the offset which shifts all times
+func Parse(text string) ([]Line, error) {
+	lines := make([]Line, 0)
+	var offset time.Duration
+
+	for idx, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		parseErr := func(format string, args ...any) error {
+			return &ParseError{Line: idx + 1, Message: fmt.Sprintf(format, args...)}
+		}
+
+		rest := strings.TrimSpace(raw)
+		if rest == "" {
+			continue
+		}
+
+		//the time and the metadata tags go before the text of the line
+		times := make([]time.Duration, 0, 1)
+		for strings.HasPrefix(rest, "[") {
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, parseErr("tag isn't closed")
+			}
+
+			tag := rest[1:end]
+			rest = strings.TrimSpace(rest[end+1:])
+
+			if start, ok := parseTimestamp(tag); ok {
+				times = append(times, start)
+				continue
+			}
+
+			name, value, ok := strings.Cut(tag, ":")
+			if !ok || !isMetadataName(name) {
+				return nil, parseErr("invalid tag [%s], must be a time [mm:ss.xx] or a metadata tag", tag)
+			}
+
+			if strings.EqualFold(strings.TrimSpace(name), "offset") {
+				ms, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+				if err != nil {
+					return nil, parseErr("invalid offset [%s], must be a number of milliseconds", tag)
+				}
+				offset = time.Duration(ms) * time.Millisecond
+			}
+		}
+
+		if len(times) == 0 {
+			if rest != "" {
+				return nil, parseErr("line has no time tag")
+			}
+			continue
+		}
+
+		lineText, words, err := parseWords(rest)
+		if err != nil {
+			return nil, parseErr("%s", err.Error())
+		}
+
+		for _, start := range times {
+			lines = append(lines, Line{Start: start, Text: lineText, Words: words})
+		}
+	}
+
+	//the positive offset shows the lyrics earlier
+	if offset != 0 {
+		for idx := range lines {
+			lines[idx].Start = max(lines[idx].Start-offset, 0)
+			if lines[idx].Words == nil {
+				continue
+			}
+
+			words := make([]Word, len(lines[idx].Words))
+			for wordIdx, word := range lines[idx].Words {
+				words[wordIdx] = Word{Start: max(word.Start-offset, 0), Text: word.Text}
+			}
+			lines[idx].Words = words
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Start < lines[j].Start
+	})
+
+	return lines, nil
+}
+
+// parseWords parses the text of the line with the optional word time tags <mm:ss.xx> of the enhanced LRC
+func parseWords(rest string) (string, []Word, error) {
+	if !strings.Contains(rest, "<") {
+		return rest, nil, nil
+	}
+
+	var (
+		words []Word
+		texts []string
+		start time.Duration
+		timed bool
+	)
+
+	for rest != "" {
+		if strings.HasPrefix(rest, "<") {
+			end := strings.Index(rest, ">")
+			if end == -1 {
+				return "", nil, fmt.Errorf("word tag isn't closed")
+			}
+
+			wordStart, ok := parseTimestamp(rest[1:end])
+			if !ok {
+				return "", nil, fmt.Errorf("invalid word tag %s, must be a time <mm:ss.xx>", rest[:end+1])
+			}
+
+			if timed && wordStart < start {
+				return "", nil, fmt.Errorf("word tag %s goes before the previous word", rest[:end+1])
+			}
+
+			start, timed = wordStart, true
+			rest = rest[end+1:]
+			continue
+		}
+
+		next := strings.Index(rest, "<")
+		if next == -1 {
+			next = len(rest)
+		}
+
+		//the last tag of the line can mark the end of the last word, so the tag without the word is skipped
+		word := strings.TrimSpace(rest[:next])
+		rest = rest[next:]
+		if word == "" {
+			continue
+		}
+
+		if !timed {
+			return "", nil, fmt.Errorf("word %q has no time tag", word)
+		}
+
+		words = append(words, Word{Start: start, Text: word})
+		texts = append(texts, word)
+	}
+
+	return strings.Join(texts, " "), words, nil
+}
+
+// parseTimestamp parses the time of the tag: mm:ss, mm:ss.xx or mm:ss.xxx, the fraction can be separated by the colon
+func parseTimestamp(tag string) (time.Duration, bool) {
+	minutes, rest, ok := strings.Cut(strings.TrimSpace(tag), ":")
+	if !ok {
+		return 0, false
+	}
+
+	seconds, fraction, hasFraction := strings.Cut(rest, ".")
+	if !hasFraction {
+		seconds, fraction, hasFraction = strings.Cut(rest, ":")
+	}
+
+	if !isDigits(minutes) || !isDigits(seconds) || len(seconds) != 2 {
+		return 0, false
+	}
+
+	if hasFraction && (!isDigits(fraction) || len(fraction) > 3) {
+		return 0, false
+	}
+
+	m, _ := strconv.ParseInt(minutes, 10, 64)
+	s, _ := strconv.ParseInt(seconds, 10, 64)
+	if s >= 60 {
+		return 0, false
+	}
+
+	//the fraction is the part of the second: .5 and .50 are 500ms
+	var ms int64
+	if hasFraction {
+		ms, _ = strconv.ParseInt((fraction + "00")[:3], 10, 64)
+	}
+
+	return time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(ms)*time.Millisecond, true
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isMetadataName checks if the name of the tag is the name of the metadata: the latin letters only, as [ar:], [ti:], [offset:]
+func isMetadataName(name string) bool {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Format formats the lines in the LRC format, the lines with the words are formatted in the enhanced LRC format
+func Format(lines []Line) string {
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString("[" + formatTimestamp(line.Start) + "]")
+
+		if len(line.Words) == 0 {
+			builder.WriteString(line.Text)
+		}
+
+		for idx, word := range line.Words {
+			if idx != 0 {
+				builder.WriteByte(' ')
+			}
+			builder.WriteString("<" + formatTimestamp(word.Start) + ">" + word.Text)
+		}
+
+		builder.WriteByte('\n')
+	}
+	return builder.String()
+}
+
+// formatTimestamp formats the time as mm:ss.xx, the milliseconds are kept as mm:ss.xxx
+func formatTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	minutes, seconds, fraction := ms/60000, ms/1000%60, ms%1000
+
+	if fraction%10 == 0 {
+		return fmt.Sprintf("%02d:%02d.%02d", minutes, seconds, fraction/10)
+	}
+	return fmt.Sprintf("%02d:%02d.%03d", minutes, seconds, fraction)
+}
+
+// At returns the index of the line sung at the time t, -1 if t is before the first line.
+// The lines must be ordered by their time, the last line lasts until the end of the song
+func At(lines []Line, t time.Duration) int {
+	return sort.Search(len(lines), func(i int) bool {
+		return lines[i].Start > t
+	}) - 1
+}
@@ -0,0 +1,123 @@
+package lrc_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/amicie-monami/music-library/pkg/lrc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ms(value int64) time.Duration {
+	return time.Duration(value) * time.Millisecond
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Text        string
+		Lines       []lrc.Line
+		ErrLine     int
+	}{
+		{
+			Description: "Simple lines with metadata",
+			Text:        "[ar:Group]\n[ti:Song]\n[00:12.00]First line\n[00:17.20]Second line\n",
+			Lines: []lrc.Line{
+				{Start: ms(12000), Text: "First line"},
+				{Start: ms(17200), Text: "Second line"},
+			},
+		},
+		{
+			Description: "Repeated line is ordered by time",
+			Text:        "[00:10.00][01:10.00]Chorus\n[00:40.50]Verse",
+			Lines: []lrc.Line{
+				{Start: ms(10000), Text: "Chorus"},
+				{Start: ms(40500), Text: "Verse"},
+				{Start: ms(70000), Text: "Chorus"},
+			},
+		},
+		{
+			Description: "Milliseconds and instrumental break",
+			Text:        "[00:01.5]One\r\n[00:02.123]\r\n[00:03:25]Two",
+			Lines: []lrc.Line{
+				{Start: ms(1500), Text: "One"},
+				{Start: ms(2123), Text: ""},
+				{Start: ms(3250), Text: "Two"},
+			},
+		},
+		{
+			Description: "Offset",
+			Text:        "[offset:+500]\n[00:00.20]Early\n[00:10.00]Late",
+			Lines: []lrc.Line{
+				{Start: 0, Text: "Early"},
+				{Start: ms(9500), Text: "Late"},
+			},
+		},
+		{
+			Description: "Enhanced words",
+			Text:        "[00:12.00]<00:12.00>Hello <00:12.50>big  <00:13.10>world<00:14.00>",
+			Lines: []lrc.Line{
+				{Start: ms(12000), Text: "Hello big world", Words: []lrc.Word{
+					{Start: ms(12000), Text: "Hello"},
+					{Start: ms(12500), Text: "big"},
+					{Start: ms(13100), Text: "world"},
+				}},
+			},
+		},
+		{
+			Description: "Line without time tag",
+			Text:        "[00:12.00]First line\nSecond line",
+			ErrLine:     2,
+		},
+		{
+			Description: "Invalid time tag",
+			Text:        "[00:75.00]First line",
+			ErrLine:     1,
+		},
+		{
+			Description: "Word without time tag",
+			Text:        "[00:12.00]Hello <00:12.50>world",
+			ErrLine:     1,
+		},
+		{
+			Description: "Words out of order",
+			Text:        "[00:12.00]<00:12.50>Hello <00:12.00>world",
+			ErrLine:     1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			lines, err := lrc.Parse(tc.Text)
+
+			if tc.ErrLine != 0 {
+				var parseErr *lrc.ParseError
+				require.ErrorAs(t, err, &parseErr)
+				assert.Equal(t, tc.ErrLine, parseErr.Line)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Lines, lines)
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	text := "[00:12.00]First line\n[00:17.205]\n[01:02.50]<01:02.50>Hello <01:03.00>world\n"
+
+	lines, err := lrc.Parse(text)
+	require.NoError(t, err)
+	assert.Equal(t, text, lrc.Format(lines))
+}
+
+func TestAt(t *testing.T) {
+	lines := []lrc.Line{{Start: ms(1000)}, {Start: ms(5000)}, {Start: ms(9000)}}
+
+	assert.Equal(t, -1, lrc.At(lines, ms(500)))
+	assert.Equal(t, 0, lrc.At(lines, ms(1000)))
+	assert.Equal(t, 1, lrc.At(lines, ms(8999)))
+	assert.Equal(t, 2, lrc.At(lines, ms(93400)))
+	assert.Equal(t, -1, lrc.At(nil, ms(1000)))
+}
@@ -9,7 +9,7 @@ import (
 // Log middleware logs the request metadata
 func Log(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		slog.Info(fmt.Sprintf("new request, %s %s [%s]", r.Method, r.URL.Path, r.Proto))
+		slog.Info(fmt.Sprintf("new request, %s %s [%s]", r.Method, r.URL.Path, r.Proto), "request_id", GetRequestID(r.Context()))
 		next.ServeHTTP(w, r)
 	})
 }
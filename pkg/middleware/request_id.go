@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header of the request id, the id of the client is kept if it is valid
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the maximum length of the request id sent by the client
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID middleware puts the id of the request into the context and the response header
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// GetRequestID returns the id of the request, the empty string if the request has passed by the middleware
+func GetRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// isValidRequestID reports whether the id consists of the printable ascii characters and isn't too long
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for idx := 0; idx < len(id); idx++ {
+		if id[idx] < '!' || id[idx] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,51 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	testCases := []struct {
+		Description string
+		Header      string
+		Kept        bool
+	}{
+		{"Id of the client", "abc-123", true},
+		{"Missing id", "", false},
+		{"Id with spaces", "abc 123", false},
+		{"Too long id", strings.Repeat("a", 129), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			var contextID string
+			handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contextID = middleware.GetRequestID(r.Context())
+			}))
+
+			request := httptest.NewRequest("GET", "/", nil)
+			if tc.Header != "" {
+				request.Header.Set(middleware.RequestIDHeader, tc.Header)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, request)
+
+			responseID := rr.Header().Get(middleware.RequestIDHeader)
+			assert.NotEmpty(t, responseID)
+			assert.Equal(t, responseID, contextID)
+
+			if tc.Kept {
+				assert.Equal(t, tc.Header, responseID)
+			} else {
+				assert.Len(t, responseID, 32)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package textdiff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// kinds of the lines of the edit script
+const (
+	Equal  = ' '
+	Delete = '-'
+	Insert = '+'
+)
+
+// Line is the line of the edit script. FromLine and ToLine are the numbers of the line in the texts
+// starting from 1, zero if the text doesn't have the line
+type Line struct {
+	Kind     byte
+	Text     string
+	FromLine int
+	ToLine   int
+}
+
+// String returns the line in the unified format: the kind followed by the text
+func (l Line) String() string {
+	return string(l.Kind) + l.Text
+}
+
+// Hunk is the group of the changed lines with the context lines around them.
+// Section is the optional heading of the hunk printed after its ranges
+type Hunk struct {
+	FromLine  int
+	FromCount int
+	ToLine    int
+	ToCount   int
+	Section   string
+	Lines     []Line
+}
+
+// Header returns the header of the hunk in the unified format
+func (h *Hunk) Header() string {
+	header := fmt.Sprintf("@@ -%s +%s @@", unifiedRange(h.FromLine, h.FromCount), unifiedRange(h.ToLine, h.ToCount))
+	if h.Section != "" {
+		header += " " + h.Section
+	}
+	return header
+}
+
+// unifiedRange formats the range of the hunk, the empty range starts at the line before it
+func unifiedRange(start int, count int) string {
+	if count == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Lines returns the shortest edit script which turns the lines a into the lines b.
+// The script is built by the longest common subsequence, the deleted lines go before the inserted ones
+func Lines(a, b []string) []Line {
+	//the common prefix and suffix don't need the quadratic table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	middleA, middleB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	//lcs[i][j] is the length of the longest common subsequence of middleA[i:] and middleB[j:]
+	width := len(middleB) + 1
+	lcs := make([]int32, (len(middleA)+1)*width)
+	for i := len(middleA) - 1; i >= 0; i-- {
+		for j := len(middleB) - 1; j >= 0; j-- {
+			if middleA[i] == middleB[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	edits := make([]Line, 0, len(a)+len(b)-prefix-suffix)
+	from, to := 1, 1
+	equal := func(text string) {
+		edits = append(edits, Line{Kind: Equal, Text: text, FromLine: from, ToLine: to})
+		from, to = from+1, to+1
+	}
+
+	for _, text := range a[:prefix] {
+		equal(text)
+	}
+
+	i, j := 0, 0
+	for i < len(middleA) || j < len(middleB) {
+		switch {
+		case i < len(middleA) && j < len(middleB) && middleA[i] == middleB[j]:
+			equal(middleA[i])
+			i, j = i+1, j+1
+		case j == len(middleB) || (i < len(middleA) && lcs[(i+1)*width+j] >= lcs[i*width+j+1]):
+			edits = append(edits, Line{Kind: Delete, Text: middleA[i], FromLine: from})
+			from, i = from+1, i+1
+		default:
+			edits = append(edits, Line{Kind: Insert, Text: middleB[j], ToLine: to})
+			to, j = to+1, j+1
+		}
+	}
+
+	for _, text := range a[len(a)-suffix:] {
+		equal(text)
+	}
+
+	return edits
+}
+
+// Hunks groups the changes of the edit script into the hunks with up to context equal lines around the changes.
+// The changes separated by no more than two contexts are joined into one hunk
+func Hunks(edits []Line, context int) []*Hunk {
+	hunks := make([]*Hunk, 0)
+
+	for idx := 0; idx < len(edits); idx++ {
+		if edits[idx].Kind == Equal {
+			continue
+		}
+
+		start := max(idx-context, 0)
+		end := idx
+		//extend the hunk while the next change is close enough
+		for next := idx + 1; next < len(edits) && next-end <= 2*context+1; next++ {
+			if edits[next].Kind != Equal {
+				end = next
+			}
+		}
+		end = min(end+context, len(edits)-1)
+
+		hunks = append(hunks, newHunk(edits, start, end))
+		idx = end
+	}
+
+	return hunks
+}
+
+func newHunk(edits []Line, start int, end int) *Hunk {
+	hunk := &Hunk{Lines: edits[start : end+1]}
+
+	for _, line := range hunk.Lines {
+		if line.Kind != Insert {
+			if hunk.FromCount == 0 {
+				hunk.FromLine = line.FromLine
+			}
+			hunk.FromCount++
+		}
+		if line.Kind != Delete {
+			if hunk.ToCount == 0 {
+				hunk.ToLine = line.ToLine
+			}
+			hunk.ToCount++
+		}
+	}
+
+	//the empty range starts at the line before the hunk
+	if hunk.FromCount == 0 {
+		hunk.FromLine = lastLine(edits[:start], func(line Line) int { return line.FromLine })
+	}
+	if hunk.ToCount == 0 {
+		hunk.ToLine = lastLine(edits[:start], func(line Line) int { return line.ToLine })
+	}
+
+	return hunk
+}
+
+// lastLine returns the last non-zero number of the lines
+func lastLine(edits []Line, number func(line Line) int) int {
+	for idx := len(edits) - 1; idx >= 0; idx-- {
+		if n := number(edits[idx]); n != 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+// Unified formats the hunks as the unified diff of the named texts, the empty string if the texts are equal
+func Unified(fromName string, toName string, hunks []*Hunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "--- %s\n+++ %s\n", fromName, toName)
+	for _, hunk := range hunks {
+		builder.WriteString(hunk.Header())
+		builder.WriteByte('\n')
+		for _, line := range hunk.Lines {
+			builder.WriteString(line.String())
+			builder.WriteByte('\n')
+		}
+	}
+	return builder.String()
+}
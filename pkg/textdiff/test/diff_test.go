@@ -0,0 +1,95 @@
+package textdiff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/amicie-monami/music-library/pkg/textdiff"
+	"github.com/stretchr/testify/assert"
+)
+
+func lines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+func TestUnified(t *testing.T) {
+	testCases := []struct {
+		Description string
+		From        string
+		To          string
+		Result      string
+	}{
+		{
+			Description: "Equal texts",
+			From:        "a\nb",
+			To:          "a\nb",
+			Result:      "",
+		},
+		{
+			Description: "Changed line",
+			From:        "a\nb\nc",
+			To:          "a\nx\nc",
+			Result:      "--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			Description: "Inserted into empty text",
+			From:        "",
+			To:          "a\nb",
+			Result:      "--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			Description: "Deleted single line",
+			From:        "a",
+			To:          "",
+			Result:      "--- from\n+++ to\n@@ -1 +0,0 @@\n-a\n",
+		},
+		{
+			Description: "Context is limited",
+			From:        "1\n2\n3\n4\n5\n6\n7\n8",
+			To:          "1\n2\n3\n4\n5\n6\n7\nx",
+			Result:      "--- from\n+++ to\n@@ -5,4 +5,4 @@\n 5\n 6\n 7\n-8\n+x\n",
+		},
+		{
+			Description: "Distant changes are separate hunks",
+			From:        "a\n1\n2\n3\n4\n5\n6\n7\nb",
+			To:          "x\n1\n2\n3\n4\n5\n6\n7\ny",
+			Result: "--- from\n+++ to\n@@ -1,4 +1,4 @@\n-a\n+x\n 1\n 2\n 3\n" +
+				"@@ -6,4 +6,4 @@\n 5\n 6\n 7\n-b\n+y\n",
+		},
+		{
+			Description: "Close changes are joined",
+			From:        "a\n1\n2\n3\n4\n5\n6\nb",
+			To:          "x\n1\n2\n3\n4\n5\n6\ny",
+			Result:      "--- from\n+++ to\n@@ -1,8 +1,8 @@\n-a\n+x\n 1\n 2\n 3\n 4\n 5\n 6\n-b\n+y\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Description, func(t *testing.T) {
+			hunks := textdiff.Hunks(textdiff.Lines(lines(tc.From), lines(tc.To)), 3)
+			assert.Equal(t, tc.Result, textdiff.Unified("from", "to", hunks))
+		})
+	}
+}
+
+func TestLinesIsShortest(t *testing.T) {
+	edits := textdiff.Lines(lines("a\nb\nc\na\nb\nb\na"), lines("c\nb\na\nb\na\nc"))
+
+	changes := 0
+	for _, line := range edits {
+		if line.Kind != textdiff.Equal {
+			changes++
+		}
+	}
+
+	//the longest common subsequence has 4 lines
+	assert.Equal(t, 5, changes)
+}
+
+func TestHunkSection(t *testing.T) {
+	hunk := &textdiff.Hunk{FromLine: 4, FromCount: 2, ToLine: 4, ToCount: 3, Section: "couplet 2"}
+	assert.Equal(t, "@@ -4,2 +4,3 @@ couplet 2", hunk.Header())
+}